@@ -32,7 +32,7 @@ func main() {
 	}
 
 	// Validate configuration
-	if cfg.YouTubeAPIKey == "" && !fileExists(cfg.OAuth2CredentialsFile) {
+	if !hasCredentials(cfg) {
 		log.Printf("Warning: No YouTube API key provided and no OAuth2 credentials file found")
 		
 		if *useJSON {
@@ -56,8 +56,8 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Create YouTube client
-	youtubeClient, err := server.NewYouTubeClient(cfg)
+	// Create a YouTube client for every configured account
+	accounts, err := server.NewAccountManager(cfg)
 	if err != nil {
 		log.Fatalf("Failed to create YouTube client: %v", err)
 	}
@@ -71,7 +71,7 @@ func main() {
 	mcpServer := mcp.NewServer(implementation, nil)
 	
 	// Register MCP tools using the official SDK
-	if err := server.SetupOfficialMCPTools(mcpServer, youtubeClient); err != nil {
+	if err := server.SetupOfficialMCPTools(mcpServer, accounts); err != nil {
 		log.Fatalf("Failed to setup MCP tools: %v", err)
 	}
 
@@ -93,6 +93,16 @@ func fileExists(filename string) bool {
 	return !os.IsNotExist(err)
 }
 
+// hasCredentials checks if any configured account has an API key or OAuth2 credentials file
+func hasCredentials(cfg *server.Config) bool {
+	for _, account := range cfg.AccountConfigs() {
+		if account.YouTubeAPIKey != "" || len(account.YouTubeAPIKeys) > 0 || fileExists(cfg.ForAccount(account).OAuth2CredentialsFile) {
+			return true
+		}
+	}
+	return false
+}
+
 // Example usage function (for documentation)
 func printUsageExamples() {
 	fmt.Println("YouTube MCP Server Usage Examples:")
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// Account is a named YouTube account with its own client and credentials
+type Account struct {
+	Name    string
+	client  *YouTubeClient
+	initErr error
+}
+
+// AccountManager routes tool calls to the client of the requested account.
+// Every account has its own HTTP client, so a revoked token or exhausted
+// quota on one account never affects the others.
+type AccountManager struct {
+	accounts    map[string]*Account
+	defaultName string
+	config      *Config
+}
+
+// NewAccountManager creates a client for every configured account.
+// Accounts that fail to initialize are kept and report their error on use;
+// only a failure of the default account is fatal.
+func NewAccountManager(cfg *Config) (*AccountManager, error) {
+	am := &AccountManager{
+		accounts: make(map[string]*Account),
+		config:   cfg,
+	}
+
+	for _, accountCfg := range cfg.AccountConfigs() {
+		if accountCfg.Name == "" {
+			return nil, fmt.Errorf("account with empty name in configuration")
+		}
+		if _, exists := am.accounts[accountCfg.Name]; exists {
+			return nil, fmt.Errorf("duplicate account name %q", accountCfg.Name)
+		}
+
+		account := &Account{Name: accountCfg.Name}
+		account.client, account.initErr = NewYouTubeClient(cfg.ForAccount(accountCfg))
+		if account.initErr != nil {
+			log.Printf("Account %q is unavailable: %v", accountCfg.Name, account.initErr)
+		}
+		am.accounts[accountCfg.Name] = account
+
+		if am.defaultName == "" {
+			am.defaultName = accountCfg.Name
+		}
+	}
+
+	if cfg.DefaultAccount != "" {
+		if _, ok := am.accounts[cfg.DefaultAccount]; !ok {
+			return nil, fmt.Errorf("default account %q is not configured", cfg.DefaultAccount)
+		}
+		am.defaultName = cfg.DefaultAccount
+	}
+
+	if am.defaultName == "" {
+		return nil, fmt.Errorf("no accounts configured")
+	}
+	if err := am.accounts[am.defaultName].initErr; err != nil {
+		return nil, err
+	}
+
+	return am, nil
+}
+
+// Client returns the client for the named account, or the default account if name is empty
+func (am *AccountManager) Client(name string) (*YouTubeClient, error) {
+	if name == "" {
+		name = am.defaultName
+	}
+
+	account, ok := am.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown account %q (available: %s)", name, strings.Join(am.Names(), ", "))
+	}
+	if account.initErr != nil {
+		return nil, fmt.Errorf("account %q is unavailable: %v", name, account.initErr)
+	}
+
+	return account.client, nil
+}
+
+// ReadOnly reports whether write tools are disabled
+func (am *AccountManager) ReadOnly() bool {
+	return am.config.ReadOnly
+}
+
+// Config returns the server configuration the accounts were created from
+func (am *AccountManager) Config() *Config {
+	return am.config
+}
+
+// Names returns the configured account names in sorted order
+func (am *AccountManager) Names() []string {
+	names := make([]string, 0, len(am.accounts))
+	for name := range am.accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultName returns the name of the account used when none is requested
+func (am *AccountManager) DefaultName() string {
+	return am.defaultName
+}
+
+// Accounts returns the configured accounts in name order
+func (am *AccountManager) Accounts() []*Account {
+	var accounts []*Account
+	for _, name := range am.Names() {
+		accounts = append(accounts, am.accounts[name])
+	}
+	return accounts
+}
+
+// Status describes the account's authentication mode and health
+func (a *Account) Status() map[string]interface{} {
+	status := map[string]interface{}{
+		"name": a.Name,
+	}
+
+	if a.initErr != nil {
+		status["status"] = "unavailable"
+		status["error"] = a.initErr.Error()
+		return status
+	}
+
+	status["auth"] = a.client.authMode
+	for k, v := range a.client.health.snapshot() {
+		status[k] = v
+	}
+	if a.client.authHealth != nil && a.client.authHealth != a.client.health {
+		status["oauth2"] = a.client.authHealth.snapshot()
+	}
+
+	return status
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxReportedCaptionErrors caps the parse errors included in an error message
+const maxReportedCaptionErrors = 10
+
+var (
+	cueTagPattern    = regexp.MustCompile(`<[^>]*>`)
+	srtTimingPattern = regexp.MustCompile(`^(\d{2,}):(\d{2}):(\d{2}),(\d{3}) --> (\d{2,}):(\d{2}):(\d{2}),(\d{3})$`)
+	vttTimingPattern = regexp.MustCompile(`^(?:(\d{2,}):)?(\d{2}):(\d{2})\.(\d{3}) --> (?:(\d{2,}):)?(\d{2}):(\d{2})\.(\d{3})(?:[ \t].*)?$`)
+)
+
+// CaptionParseError is a syntax error at a line of a caption file
+type CaptionParseError struct {
+	Line    int
+	Message string
+}
+
+func (e CaptionParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// captionFormat determines whether a caption file is SRT or WebVTT from its
+// extension, falling back to the WEBVTT header
+func captionFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".srt":
+		return "srt"
+	case ".vtt":
+		return "vtt"
+	}
+	if strings.HasPrefix(strings.TrimPrefix(string(data), "\ufeff"), "WEBVTT") {
+		return "vtt"
+	}
+	return "srt"
+}
+
+// validateCaptionFile checks the syntax of an SRT or WebVTT file, returning
+// an error listing the offending lines
+func validateCaptionFile(path string, data []byte) (string, error) {
+	format := captionFormat(path, data)
+	lines := strings.Split(strings.ReplaceAll(strings.TrimPrefix(string(data), "\ufeff"), "\r\n", "\n"), "\n")
+
+	var errs []CaptionParseError
+	if format == "vtt" {
+		errs = parseVTT(lines)
+	} else {
+		errs = parseSRT(lines)
+	}
+	if len(errs) == 0 {
+		return format, nil
+	}
+
+	var messages []string
+	for i, err := range errs {
+		if i == maxReportedCaptionErrors {
+			messages = append(messages, fmt.Sprintf("... and %d more", len(errs)-i))
+			break
+		}
+		messages = append(messages, err.Error())
+	}
+	return format, fmt.Errorf("invalid %s caption file:\n%s", strings.ToUpper(format), strings.Join(messages, "\n"))
+}
+
+// captionBlocks splits lines into blocks separated by blank lines, returning
+// the 1-based line number each block starts at
+func captionBlocks(lines []string) (blocks [][]string, starts []int) {
+	var block []string
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		if block == nil {
+			starts = append(starts, i+1)
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks, starts
+}
+
+// parseSRT validates SubRip cues: an index, a timing line and at least one line of text
+func parseSRT(lines []string) []CaptionParseError {
+	var errs []CaptionParseError
+	blocks, starts := captionBlocks(lines)
+	if len(blocks) == 0 {
+		return []CaptionParseError{{Line: 1, Message: "file contains no cues"}}
+	}
+
+	for i, block := range blocks {
+		line := starts[i]
+		if _, err := strconv.Atoi(strings.TrimSpace(block[0])); err != nil {
+			errs = append(errs, CaptionParseError{Line: line, Message: fmt.Sprintf("expected cue number, got %q", block[0])})
+			continue
+		}
+		if len(block) < 2 {
+			errs = append(errs, CaptionParseError{Line: line + 1, Message: "missing timing line"})
+			continue
+		}
+		if err := checkTiming(srtTimingPattern, strings.TrimSpace(block[1])); err != "" {
+			errs = append(errs, CaptionParseError{Line: line + 1, Message: err})
+			continue
+		}
+		if len(block) < 3 {
+			errs = append(errs, CaptionParseError{Line: line + 2, Message: "cue has no text"})
+		}
+	}
+
+	return errs
+}
+
+// parseVTT validates a WebVTT file: the header, then cues with an optional
+// identifier and a timing line. NOTE, STYLE and REGION blocks are skipped.
+func parseVTT(lines []string) []CaptionParseError {
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "WEBVTT") {
+		return []CaptionParseError{{Line: 1, Message: "missing WEBVTT header"}}
+	}
+
+	var errs []CaptionParseError
+	blocks, starts := captionBlocks(lines)
+	for i, block := range blocks[1:] {
+		line := starts[i+1]
+		first := strings.TrimSpace(block[0])
+		if strings.HasPrefix(first, "NOTE") || first == "STYLE" || first == "REGION" {
+			continue
+		}
+
+		timing := 0
+		if !strings.Contains(first, "-->") {
+			// The first line is a cue identifier
+			timing = 1
+		}
+		if timing >= len(block) {
+			errs = append(errs, CaptionParseError{Line: line + timing, Message: "missing timing line"})
+			continue
+		}
+		if err := checkTiming(vttTimingPattern, strings.TrimSpace(block[timing])); err != "" {
+			errs = append(errs, CaptionParseError{Line: line + timing, Message: err})
+		}
+	}
+
+	return errs
+}
+
+// checkTiming validates a cue timing line, returning a message if it is invalid
+func checkTiming(pattern *regexp.Regexp, line string) string {
+	m := pattern.FindStringSubmatch(line)
+	if m == nil {
+		return fmt.Sprintf("invalid timing line %q", line)
+	}
+
+	start, ok := timestamp(m[1:5])
+	if !ok {
+		return fmt.Sprintf("invalid start time in %q", line)
+	}
+	end, ok := timestamp(m[5:9])
+	if !ok {
+		return fmt.Sprintf("invalid end time in %q", line)
+	}
+	if end <= start {
+		return fmt.Sprintf("end time is not after start time in %q", line)
+	}
+	return ""
+}
+
+// timestamp converts hours, minutes, seconds and milliseconds into a duration
+func timestamp(parts []string) (time.Duration, bool) {
+	hours, _ := strconv.Atoi(parts[0])
+	minutes, _ := strconv.Atoi(parts[1])
+	seconds, _ := strconv.Atoi(parts[2])
+	millis, _ := strconv.Atoi(parts[3])
+	if minutes > 59 || seconds > 59 {
+		return 0, false
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second + time.Duration(millis)*time.Millisecond, true
+}
+
+// TranscriptLine is a line of caption text with the time it is shown
+type TranscriptLine struct {
+	Start time.Duration
+	Text  string
+}
+
+// parseTranscript extracts the text of a WebVTT file as transcript lines.
+// Cue markup is removed, and a line repeated by the next cue, as rolling
+// automatic captions do, is only kept once.
+func parseTranscript(data []byte) []TranscriptLine {
+	lines := strings.Split(strings.ReplaceAll(strings.TrimPrefix(string(data), "\ufeff"), "\r\n", "\n"), "\n")
+	blocks, _ := captionBlocks(lines)
+
+	var transcript []TranscriptLine
+	last := ""
+	for _, block := range blocks {
+		for i, line := range block {
+			m := vttTimingPattern.FindStringSubmatch(strings.TrimSpace(line))
+			if m == nil {
+				continue
+			}
+			start, _ := timestamp(m[1:5])
+			for _, text := range block[i+1:] {
+				text = strings.TrimSpace(cueTagPattern.ReplaceAllString(text, ""))
+				if text == "" || text == last {
+					continue
+				}
+				last = text
+				transcript = append(transcript, TranscriptLine{Start: start, Text: text})
+			}
+			break
+		}
+	}
+
+	return transcript
+}
+
+// formatTimestamp formats a transcript time as HH:MM:SS
+func formatTimestamp(d time.Duration) string {
+	seconds := int(d / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCaptionFormat(t *testing.T) {
+	tests := []struct {
+		path string
+		data string
+		want string
+	}{
+		{"subs.srt", "WEBVTT\n", "srt"},
+		{"subs.VTT", "1\n", "vtt"},
+		{"subs.txt", "WEBVTT\n\n00:01.000 --> 00:02.000\nHi\n", "vtt"},
+		{"subs.txt", "\ufeffWEBVTT\n", "vtt"},
+		{"subs", "1\n00:00:01,000 --> 00:00:02,000\nHi\n", "srt"},
+	}
+	for _, tt := range tests {
+		if got := captionFormat(tt.path, []byte(tt.data)); got != tt.want {
+			t.Errorf("captionFormat(%q, %q) = %q, want %q", tt.path, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestValidateCaptionFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		data    string
+		format  string
+		wantErr []string
+	}{
+		{
+			name:   "valid SRT",
+			path:   "a.srt",
+			data:   "1\n00:00:01,000 --> 00:00:02,500\nHello\n\n2\n00:00:03,000 --> 00:00:04,000\nTwo\nlines\n",
+			format: "srt",
+		},
+		{
+			name:   "valid SRT with CRLF and BOM",
+			path:   "a.srt",
+			data:   "\ufeff1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\n",
+			format: "srt",
+		},
+		{
+			name:    "empty SRT",
+			path:    "a.srt",
+			data:    "\n\n",
+			format:  "srt",
+			wantErr: []string{"line 1: file contains no cues"},
+		},
+		{
+			name:    "SRT errors are reported by line",
+			path:    "a.srt",
+			data:    "one\n00:00:01,000 --> 00:00:02,000\nHi\n\n2\n00:00:02.000 --> 00:00:03.000\nHi\n\n3\n00:00:05,000 --> 00:00:04,000\nHi\n\n4\n00:00:06,000 --> 00:00:07,000\n",
+			format:  "srt",
+			wantErr: []string{`line 1: expected cue number, got "one"`, "line 6: invalid timing line", "line 10: end time is not after start time", "line 15: cue has no text"},
+		},
+		{
+			name:    "SRT minutes out of range",
+			path:    "a.srt",
+			data:    "1\n00:61:00,000 --> 00:62:00,000\nHi\n",
+			format:  "srt",
+			wantErr: []string{"line 2: invalid start time"},
+		},
+		{
+			name:   "valid VTT with identifiers, settings, notes and short timestamps",
+			path:   "a.vtt",
+			data:   "WEBVTT - title\n\nNOTE a comment\n\nSTYLE\n::cue { color: red }\n\nintro\n00:01.000 --> 00:02.000 align:start\nHello\n\n01:00:00.000 --> 01:00:01.000\n<v Bob>Hi</v>\n",
+			format: "vtt",
+		},
+		{
+			name:    "VTT without header",
+			path:    "a.vtt",
+			data:    "00:01.000 --> 00:02.000\nHello\n",
+			format:  "vtt",
+			wantErr: []string{"line 1: missing WEBVTT header"},
+		},
+		{
+			name:    "VTT with SRT style timing and a missing timing line",
+			path:    "a.vtt",
+			data:    "WEBVTT\n\n00:00:01,000 --> 00:00:02,000\nHello\n\ncue-2\n",
+			format:  "vtt",
+			wantErr: []string{"line 3: invalid timing line", "line 7: missing timing line"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := validateCaptionFile(tt.path, []byte(tt.data))
+			if format != tt.format {
+				t.Errorf("format = %q, want %q", format, tt.format)
+			}
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %q", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCaptionFileCapsReportedErrors(t *testing.T) {
+	var data strings.Builder
+	for range maxReportedCaptionErrors + 5 {
+		data.WriteString("x\n00:00:01,000 --> 00:00:02,000\nHi\n\n")
+	}
+
+	_, err := validateCaptionFile("a.srt", []byte(data.String()))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := strings.Count(err.Error(), "expected cue number"); got != maxReportedCaptionErrors {
+		t.Errorf("reported %d errors, want %d", got, maxReportedCaptionErrors)
+	}
+	if !strings.Contains(err.Error(), "... and 5 more") {
+		t.Errorf("error %q does not mention the remaining errors", err)
+	}
+}
@@ -4,33 +4,88 @@ import (
 	"encoding/json"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
+	"google.golang.org/api/youtube/v3"
 )
 
 // Config holds the configuration for the YouTube MCP server
 type Config struct {
 	// YouTube API Key - can be used for public data access
 	YouTubeAPIKey string `json:"youtube_api_key"`
-	
+
+	// Additional API keys, e.g. from several Google Cloud projects, pooled
+	// together with YouTubeAPIKey to spread the daily quota
+	YouTubeAPIKeys []string `json:"youtube_api_keys,omitempty"`
+
+	// How a key is picked from the pool: round_robin, least_used or failover
+	APIKeyStrategy string `json:"api_key_strategy,omitempty"`
+
 	// OAuth2 credentials file path for user-specific operations
 	OAuth2CredentialsFile string `json:"oauth2_credentials_file"`
-	
+
 	// Token file path to store OAuth2 tokens
 	TokenFile string `json:"token_file"`
-	
+
+	// Named account profiles, each with its own credentials. The top-level
+	// credentials above form the implicit "default" account.
+	Accounts []AccountConfig `json:"accounts,omitempty"`
+
+	// Account used when a tool call does not name one
+	DefaultAccount string `json:"default_account,omitempty"`
+
+	// ReadOnly disables all tools that modify data. Turning it off requests the
+	// youtube.force-ssl OAuth2 scope, which requires re-authorizing the token.
+	ReadOnly bool `json:"read_only"`
+
+	// EnableCommentTools registers the comment posting and moderation tools.
+	// It has no effect while ReadOnly is set.
+	EnableCommentTools bool `json:"enable_comment_tools,omitempty"`
+
+	// EnableChatModerationTools registers the live chat ban and moderator tools.
+	// It has no effect while ReadOnly is set.
+	EnableChatModerationTools bool `json:"enable_chat_moderation_tools,omitempty"`
+
+	// EnableMemberships registers the channel membership tools and requests the
+	// youtube.channel-memberships.creator OAuth2 scope, which requires
+	// re-authorizing the token
+	EnableMemberships bool `json:"enable_memberships,omitempty"`
+
+	// UploadChunkSizeMB is the chunk size for resumable uploads, in MiB
+	UploadChunkSizeMB int `json:"upload_chunk_size_mb,omitempty"`
+
+	// APIEndpoint overrides the YouTube API base URL, e.g. to point the
+	// server at a local stand-in for testing
+	APIEndpoint string `json:"api_endpoint,omitempty"`
+
 	// Server configuration
 	ServerName        string `json:"server_name"`
 	ServerVersion     string `json:"server_version"`
 	ServerDescription string `json:"server_description"`
 }
 
+// AccountConfig holds the credentials for a single named YouTube account
+type AccountConfig struct {
+	Name                  string   `json:"name"`
+	YouTubeAPIKey         string   `json:"youtube_api_key,omitempty"`
+	YouTubeAPIKeys        []string `json:"youtube_api_keys,omitempty"`
+	APIKeyStrategy        string   `json:"api_key_strategy,omitempty"`
+	OAuth2CredentialsFile string   `json:"oauth2_credentials_file,omitempty"`
+	TokenFile             string   `json:"token_file,omitempty"`
+}
+
+// DefaultAccountName is the name of the account built from the top-level credentials
+const DefaultAccountName = "default"
+
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
 	return &Config{
 		YouTubeAPIKey:         os.Getenv("YOUTUBE_API_KEY"),
 		OAuth2CredentialsFile: "client_secret.json",
 		TokenFile:             "token.json",
+		ReadOnly:              true,
 		ServerName:            "youtube-mcp-server",
 		ServerVersion:         "1.0.0",
 		ServerDescription:     "YouTube Data API v3 MCP Server for video search, channel info, and more",
@@ -43,19 +98,53 @@ func LoadConfig() (*Config, error) {
 	if err := godotenv.Load(); err != nil {
 		log.Printf("No .env file found or error loading it: %v", err)
 	}
-	
+
 	config := DefaultConfig()
-	
+
 	// Load from environment variables (which now include .env values)
 	if apiKey := os.Getenv("YOUTUBE_API_KEY"); apiKey != "" {
 		config.YouTubeAPIKey = apiKey
 	}
+	if apiKeys := os.Getenv("YOUTUBE_API_KEYS"); apiKeys != "" {
+		config.YouTubeAPIKeys = splitList(apiKeys)
+	}
+	if strategy := os.Getenv("API_KEY_STRATEGY"); strategy != "" {
+		config.APIKeyStrategy = strategy
+	}
 	if credsFile := os.Getenv("OAUTH2_CREDENTIALS_FILE"); credsFile != "" {
 		config.OAuth2CredentialsFile = credsFile
 	}
 	if tokenFile := os.Getenv("TOKEN_FILE"); tokenFile != "" {
 		config.TokenFile = tokenFile
 	}
+	if accounts := os.Getenv("YOUTUBE_ACCOUNTS"); accounts != "" {
+		config.Accounts = accountsFromEnv(accounts)
+	}
+	if defaultAccount := os.Getenv("DEFAULT_ACCOUNT"); defaultAccount != "" {
+		config.DefaultAccount = defaultAccount
+	}
+	if readOnly := os.Getenv("READ_ONLY"); readOnly != "" {
+		if value, err := strconv.ParseBool(readOnly); err == nil {
+			config.ReadOnly = value
+		} else {
+			log.Printf("Ignoring invalid READ_ONLY value %q (expected true or false), staying read-only", readOnly)
+		}
+	}
+	if enableComments, err := strconv.ParseBool(os.Getenv("ENABLE_COMMENT_TOOLS")); err == nil {
+		config.EnableCommentTools = enableComments
+	}
+	if enableModeration, err := strconv.ParseBool(os.Getenv("ENABLE_CHAT_MODERATION_TOOLS")); err == nil {
+		config.EnableChatModerationTools = enableModeration
+	}
+	if enableMemberships, err := strconv.ParseBool(os.Getenv("ENABLE_MEMBERSHIPS")); err == nil {
+		config.EnableMemberships = enableMemberships
+	}
+	if chunkSize, err := strconv.Atoi(os.Getenv("UPLOAD_CHUNK_SIZE_MB")); err == nil {
+		config.UploadChunkSizeMB = chunkSize
+	}
+	if endpoint := os.Getenv("YOUTUBE_API_ENDPOINT"); endpoint != "" {
+		config.APIEndpoint = endpoint
+	}
 	if serverName := os.Getenv("SERVER_NAME"); serverName != "" {
 		config.ServerName = serverName
 	}
@@ -65,42 +154,156 @@ func LoadConfig() (*Config, error) {
 	if serverDesc := os.Getenv("SERVER_DESCRIPTION"); serverDesc != "" {
 		config.ServerDescription = serverDesc
 	}
-	
+
 	return config, nil
 }
 
 // LoadConfigFromJSON loads configuration from a JSON file (legacy support)
 func LoadConfigFromJSON(filename string) (*Config, error) {
 	config := DefaultConfig()
-	
+
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
 		return config, nil
 	}
-	
+
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	err = json.Unmarshal(data, config)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	// Override with environment variables if they exist
 	if apiKey := os.Getenv("YOUTUBE_API_KEY"); apiKey != "" {
 		config.YouTubeAPIKey = apiKey
 	}
-	
+
 	return config, nil
 }
 
+// accountsFromEnv builds account profiles from a comma-separated list of names.
+// Each account NAME reads YOUTUBE_<NAME>_API_KEY, YOUTUBE_<NAME>_API_KEYS,
+// YOUTUBE_<NAME>_API_KEY_STRATEGY, YOUTUBE_<NAME>_OAUTH2_CREDENTIALS_FILE
+// and YOUTUBE_<NAME>_TOKEN_FILE.
+func accountsFromEnv(names string) []AccountConfig {
+	var accounts []AccountConfig
+	for _, name := range splitList(names) {
+		prefix := "YOUTUBE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		accounts = append(accounts, AccountConfig{
+			Name:                  name,
+			YouTubeAPIKey:         os.Getenv(prefix + "API_KEY"),
+			YouTubeAPIKeys:        splitList(os.Getenv(prefix + "API_KEYS")),
+			APIKeyStrategy:        os.Getenv(prefix + "API_KEY_STRATEGY"),
+			OAuth2CredentialsFile: os.Getenv(prefix + "OAUTH2_CREDENTIALS_FILE"),
+			TokenFile:             os.Getenv(prefix + "TOKEN_FILE"),
+		})
+	}
+	return accounts
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// APIKeys returns the single API key followed by the pooled keys, without duplicates
+func (c *Config) APIKeys() []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range append([]string{c.YouTubeAPIKey}, c.YouTubeAPIKeys...) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// AccountConfigs returns every configured account, starting with the implicit
+// default account when top-level credentials are set
+func (c *Config) AccountConfigs() []AccountConfig {
+	var accounts []AccountConfig
+	if len(c.APIKeys()) > 0 || fileExists(c.OAuth2CredentialsFile) || len(c.Accounts) == 0 {
+		accounts = append(accounts, AccountConfig{
+			Name:                  DefaultAccountName,
+			YouTubeAPIKey:         c.YouTubeAPIKey,
+			YouTubeAPIKeys:        c.YouTubeAPIKeys,
+			APIKeyStrategy:        c.APIKeyStrategy,
+			OAuth2CredentialsFile: c.OAuth2CredentialsFile,
+			TokenFile:             c.TokenFile,
+		})
+	}
+	return append(accounts, c.Accounts...)
+}
+
+// ForAccount returns a copy of the config with the account's credentials in place
+// of the top-level ones. Only the OAuth2 credentials file falls back to the
+// top-level value when unset; API keys are never shared between accounts, and
+// the token file defaults to <name>_token.json.
+func (c *Config) ForAccount(account AccountConfig) *Config {
+	cfg := *c
+	cfg.Accounts = nil
+	cfg.DefaultAccount = ""
+	if account.Name == DefaultAccountName {
+		return &cfg
+	}
+	cfg.YouTubeAPIKey = account.YouTubeAPIKey
+	cfg.YouTubeAPIKeys = account.YouTubeAPIKeys
+	cfg.APIKeyStrategy = account.APIKeyStrategy
+	if account.OAuth2CredentialsFile != "" {
+		cfg.OAuth2CredentialsFile = account.OAuth2CredentialsFile
+	}
+	cfg.TokenFile = account.TokenFile
+	if cfg.TokenFile == "" {
+		cfg.TokenFile = account.Name + "_token.json"
+	}
+	return &cfg
+}
+
+// OAuthScopes returns the OAuth2 scopes to request for the configured features
+func (c *Config) OAuthScopes() []string {
+	scopes := []string{youtube.YoutubeForceSslScope}
+	if c.ReadOnly {
+		scopes = []string{youtube.YoutubeReadonlyScope}
+	}
+	if c.EnableMemberships {
+		scopes = append(scopes, youtube.YoutubeChannelMembershipsCreatorScope)
+	}
+	return scopes
+}
+
+// UploadChunkSize returns the resumable upload chunk size in bytes (8 MiB by default)
+func (c *Config) UploadChunkSize() int {
+	if c.UploadChunkSizeMB <= 0 {
+		return 8 << 20
+	}
+	return c.UploadChunkSizeMB << 20
+}
+
+// fileExists checks if a file exists
+func fileExists(filename string) bool {
+	if filename == "" {
+		return false
+	}
+	_, err := os.Stat(filename)
+	return !os.IsNotExist(err)
+}
+
 // SaveConfig saves configuration to a JSON file
 func (c *Config) SaveConfig(filename string) error {
 	data, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
 		return err
 	}
-	
+
 	return os.WriteFile(filename, data, 0644)
 }
@@ -0,0 +1,33 @@
+package server
+
+import "testing"
+
+func TestLoadConfigReadOnly(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", true},
+		{"false", false},
+		{"FALSE", false},
+		{"False", false},
+		{"f", false},
+		{"0", false},
+		{"true", true},
+		{"1", true},
+		{"T", true},
+		{"no", true},
+		{"off", true},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("READ_ONLY", tt.value)
+		config, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("LoadConfig: %v", err)
+		}
+		if config.ReadOnly != tt.want {
+			t.Errorf("READ_ONLY=%q: read-only = %v, want %v", tt.value, config.ReadOnly, tt.want)
+		}
+	}
+}
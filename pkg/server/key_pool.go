@@ -0,0 +1,217 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// API key selection strategies
+const (
+	KeyStrategyRoundRobin = "round_robin"
+	KeyStrategyLeastUsed  = "least_used"
+	KeyStrategyFailover   = "failover"
+)
+
+// pooledKey tracks the usage of a single API key
+type pooledKey struct {
+	key            string
+	requests       int64
+	quotaErrors    int64
+	exhaustedUntil time.Time
+}
+
+// keyPool is an http.RoundTripper that spreads requests over several API keys.
+// A key that hits quotaExceeded is set aside until the daily quota reset and
+// the request is retried transparently with the next available key.
+type keyPool struct {
+	strategy string
+	base     http.RoundTripper
+
+	mu   sync.Mutex
+	keys []*pooledKey
+	next int
+}
+
+// newKeyPool creates a key pool using the given strategy (round_robin by default)
+func newKeyPool(keys []string, strategy string, base http.RoundTripper) (*keyPool, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no API keys configured")
+	}
+
+	switch strategy {
+	case "":
+		strategy = KeyStrategyRoundRobin
+	case KeyStrategyRoundRobin, KeyStrategyLeastUsed, KeyStrategyFailover:
+	default:
+		return nil, fmt.Errorf("unknown API key strategy %q (expected %s, %s or %s)",
+			strategy, KeyStrategyRoundRobin, KeyStrategyLeastUsed, KeyStrategyFailover)
+	}
+
+	pool := &keyPool{strategy: strategy, base: base}
+	for _, key := range keys {
+		pool.keys = append(pool.keys, &pooledKey{key: key})
+	}
+	return pool, nil
+}
+
+// pick selects the next available key, skipping those already tried for this request
+func (p *keyPool) pick(tried map[int]bool) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	available := func(i int) bool {
+		return p.available(i, tried, now)
+	}
+
+	selected := -1
+	switch p.strategy {
+	case KeyStrategyRoundRobin:
+		for n := 0; n < len(p.keys); n++ {
+			i := (p.next + n) % len(p.keys)
+			if available(i) {
+				selected = i
+				p.next = (i + 1) % len(p.keys)
+				break
+			}
+		}
+	case KeyStrategyLeastUsed:
+		for i, key := range p.keys {
+			if available(i) && (selected < 0 || key.requests < p.keys[selected].requests) {
+				selected = i
+			}
+		}
+	case KeyStrategyFailover:
+		for i := range p.keys {
+			if available(i) {
+				selected = i
+				break
+			}
+		}
+	}
+
+	if selected < 0 {
+		return -1, fmt.Errorf("all %d API keys have exhausted their quota until %s",
+			len(p.keys), p.earliestReset().Format(time.RFC3339))
+	}
+
+	p.keys[selected].requests++
+	return selected, nil
+}
+
+// available reports whether key i is neither exhausted nor already tried.
+// The caller must hold p.mu.
+func (p *keyPool) available(i int, tried map[int]bool, now time.Time) bool {
+	return !tried[i] && !now.Before(p.keys[i].exhaustedUntil)
+}
+
+// hasAvailable reports whether any key not yet tried for this request is left
+func (p *keyPool) hasAvailable(tried map[int]bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for i := range p.keys {
+		if p.available(i, tried, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// earliestReset returns when the first exhausted key becomes available again
+func (p *keyPool) earliestReset() time.Time {
+	var earliest time.Time
+	for _, key := range p.keys {
+		if earliest.IsZero() || key.exhaustedUntil.Before(earliest) {
+			earliest = key.exhaustedUntil
+		}
+	}
+	return earliest
+}
+
+// markExhausted sets a key aside until the next daily quota reset
+func (p *keyPool) markExhausted(i int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[i].quotaErrors++
+	p.keys[i].exhaustedUntil = nextQuotaReset(time.Now())
+}
+
+// RoundTrip implements http.RoundTripper
+func (p *keyPool) RoundTrip(req *http.Request) (*http.Response, error) {
+	tried := make(map[int]bool)
+
+	for {
+		i, err := p.pick(tried)
+		if err != nil {
+			return nil, err
+		}
+		tried[i] = true
+
+		attempt := req.Clone(req.Context())
+		if len(tried) > 1 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+		query := attempt.URL.Query()
+		query.Set("key", p.keys[i].key)
+		attempt.URL.RawQuery = query.Encode()
+
+		resp, err := p.base.RoundTrip(attempt)
+		if err != nil {
+			return nil, err
+		}
+		if !isQuotaReason(readErrorReason(resp)) {
+			return resp, nil
+		}
+
+		p.markExhausted(i)
+		// Only retry if the request body can be replayed and another key is left,
+		// otherwise the caller gets the quota error itself
+		if (req.Body != nil && req.GetBody == nil) || !p.hasAvailable(tried) {
+			return resp, nil
+		}
+		resp.Body.Close()
+	}
+}
+
+// maskKey hides all but the last four characters of an API key
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+// snapshot returns per-key usage as tool output fields
+func (p *keyPool) snapshot() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var keys []map[string]interface{}
+	for _, key := range p.keys {
+		info := map[string]interface{}{
+			"key":          maskKey(key.key),
+			"requests":     key.requests,
+			"quota_errors": key.quotaErrors,
+			"status":       "available",
+		}
+		if now.Before(key.exhaustedUntil) {
+			info["status"] = "exhausted"
+			info["resets_at"] = key.exhaustedUntil.Format(time.RFC3339)
+		}
+		keys = append(keys, info)
+	}
+
+	return map[string]interface{}{
+		"strategy": p.strategy,
+		"keys":     keys,
+	}
+}
@@ -0,0 +1,253 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// quotaBody is the error body YouTube returns when a key's daily quota is used up
+const quotaBody = `{"error":{"code":403,"errors":[{"reason":"quotaExceeded"}]}}`
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newTestPool(t *testing.T, strategy string, keys ...string) *keyPool {
+	t.Helper()
+	pool, err := newKeyPool(keys, strategy, nil)
+	if err != nil {
+		t.Fatalf("newKeyPool: %v", err)
+	}
+	return pool
+}
+
+// pickSequence picks n keys, one request each, and returns their values
+func pickSequence(t *testing.T, pool *keyPool, n int) []string {
+	t.Helper()
+	var picked []string
+	for range n {
+		i, err := pool.pick(map[int]bool{})
+		if err != nil {
+			t.Fatalf("pick: %v", err)
+		}
+		picked = append(picked, pool.keys[i].key)
+	}
+	return picked
+}
+
+func TestNewKeyPool(t *testing.T) {
+	if _, err := newKeyPool(nil, "", nil); err == nil {
+		t.Error("expected an error for an empty pool")
+	}
+	if _, err := newKeyPool([]string{"a"}, "random", nil); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+	pool := newTestPool(t, "", "a")
+	if pool.strategy != KeyStrategyRoundRobin {
+		t.Errorf("default strategy = %q, want %q", pool.strategy, KeyStrategyRoundRobin)
+	}
+}
+
+func TestKeyPoolPick(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  string
+		requests  []int64
+		exhausted []int
+		want      []string
+	}{
+		{
+			name:     "round robin cycles through keys",
+			strategy: KeyStrategyRoundRobin,
+			want:     []string{"a", "b", "c", "a", "b"},
+		},
+		{
+			name:      "round robin skips exhausted keys",
+			strategy:  KeyStrategyRoundRobin,
+			exhausted: []int{1},
+			want:      []string{"a", "c", "a", "c"},
+		},
+		{
+			name:     "least used picks the key with fewest requests",
+			strategy: KeyStrategyLeastUsed,
+			requests: []int64{5, 1, 3},
+			want:     []string{"b", "b", "b", "c", "b"},
+		},
+		{
+			name:      "least used skips exhausted keys",
+			strategy:  KeyStrategyLeastUsed,
+			requests:  []int64{5, 1, 3},
+			exhausted: []int{1},
+			want:      []string{"c", "c", "a", "c"},
+		},
+		{
+			name:     "failover sticks to the first key",
+			strategy: KeyStrategyFailover,
+			want:     []string{"a", "a", "a"},
+		},
+		{
+			name:      "failover moves on once a key is exhausted",
+			strategy:  KeyStrategyFailover,
+			exhausted: []int{0},
+			want:      []string{"b", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := newTestPool(t, tt.strategy, "a", "b", "c")
+			for i, requests := range tt.requests {
+				pool.keys[i].requests = requests
+			}
+			for _, i := range tt.exhausted {
+				pool.markExhausted(i)
+			}
+
+			got := pickSequence(t, pool, len(tt.want))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("picked %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyPoolPickSkipsTriedKeys(t *testing.T) {
+	pool := newTestPool(t, KeyStrategyFailover, "a", "b")
+	i, err := pool.pick(map[int]bool{0: true})
+	if err != nil {
+		t.Fatalf("pick: %v", err)
+	}
+	if pool.keys[i].key != "b" {
+		t.Errorf("picked %q, want b", pool.keys[i].key)
+	}
+	if _, err := pool.pick(map[int]bool{0: true, 1: true}); err == nil {
+		t.Error("expected an error once every key was tried")
+	}
+}
+
+func TestKeyPoolMarkExhausted(t *testing.T) {
+	pool := newTestPool(t, KeyStrategyRoundRobin, "a", "b")
+	before := time.Now()
+	pool.markExhausted(0)
+	pool.markExhausted(1)
+
+	for _, key := range pool.keys {
+		if key.quotaErrors != 1 {
+			t.Errorf("key %s: quotaErrors = %d, want 1", key.key, key.quotaErrors)
+		}
+		if want := nextQuotaReset(before); !key.exhaustedUntil.Equal(want) {
+			t.Errorf("key %s: exhaustedUntil = %v, want %v", key.key, key.exhaustedUntil, want)
+		}
+	}
+
+	_, err := pool.pick(map[int]bool{})
+	if err == nil || !strings.Contains(err.Error(), "all 2 API keys have exhausted their quota") {
+		t.Errorf("pick error = %v, want exhausted pool error", err)
+	}
+
+	// Once the reset has passed the key is available again
+	pool.keys[0].exhaustedUntil = time.Now().Add(-time.Second)
+	if got := pickSequence(t, pool, 1); got[0] != "a" {
+		t.Errorf("picked %q after reset, want a", got[0])
+	}
+}
+
+// quotaTransport answers quotaExceeded for the given keys and 200 otherwise,
+// recording the key of every request
+func quotaTransport(exhausted map[string]bool, seen *[]string) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		key := req.URL.Query().Get("key")
+		*seen = append(*seen, key)
+		if exhausted[key] {
+			return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(quotaBody))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	})
+}
+
+func TestKeyPoolRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		preMarked  []int
+		exhausted  map[string]bool
+		wantStatus int
+		wantSeen   []string
+	}{
+		{
+			name:       "first key succeeds",
+			wantStatus: http.StatusOK,
+			wantSeen:   []string{"a"},
+		},
+		{
+			name:       "retries with the next key on quotaExceeded",
+			exhausted:  map[string]bool{"a": true},
+			wantStatus: http.StatusOK,
+			wantSeen:   []string{"a", "b"},
+		},
+		{
+			name:       "returns the quota error when every key fails",
+			exhausted:  map[string]bool{"a": true, "b": true, "c": true},
+			wantStatus: http.StatusForbidden,
+			wantSeen:   []string{"a", "b", "c"},
+		},
+		{
+			name:       "returns the quota error when the last available key fails",
+			preMarked:  []int{1, 2},
+			exhausted:  map[string]bool{"a": true},
+			wantStatus: http.StatusForbidden,
+			wantSeen:   []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen []string
+			pool, err := newKeyPool([]string{"a", "b", "c"}, KeyStrategyFailover, quotaTransport(tt.exhausted, &seen))
+			if err != nil {
+				t.Fatalf("newKeyPool: %v", err)
+			}
+			for _, i := range tt.preMarked {
+				pool.markExhausted(i)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "https://example.com/youtube/v3/videos?part=id", nil)
+			resp, err := pool.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusForbidden {
+				body, _ := io.ReadAll(resp.Body)
+				if reason := apiErrorReason(body); reason != "quotaExceeded" {
+					t.Errorf("error reason = %q, want quotaExceeded", reason)
+				}
+			}
+			if strings.Join(seen, ",") != strings.Join(tt.wantSeen, ",") {
+				t.Errorf("keys used = %v, want %v", seen, tt.wantSeen)
+			}
+		})
+	}
+}
+
+func TestMaskKey(t *testing.T) {
+	tests := map[string]string{
+		"":             "****",
+		"abcd":         "****",
+		"AIzaSy123456": "****3456",
+	}
+	for key, want := range tests {
+		if got := maskKey(key); got != want {
+			t.Errorf("maskKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yosida95/uritemplate/v3"
+	"google.golang.org/api/youtube/v3"
+)
+
+// Resource MIME types. Every resource is returned in both renderings, the
+// template's own MIME type first, so clients can pick the one they prefer;
+// each template's description says so, since a template can only advertise one.
+const (
+	jsonMIMEType     = "application/json"
+	markdownMIMEType = "text/markdown"
+)
+
+// playlistResourceItems caps the playlist items included in a playlist resource
+const playlistResourceItems = 50
+
+// resourceReader loads a resource by ID and renders it as JSON data and Markdown
+type resourceReader func(youtubeClient *YouTubeClient, id string) (interface{}, string, error)
+
+// setupResources registers the youtube:// resource templates, which let
+// clients attach videos, channels, playlists and transcripts as context.
+// Transcripts are only registered with write access, which downloading
+// captions needs.
+func setupResources(server *mcp.Server, accounts *AccountManager) {
+	addResourceTemplate(server, accounts, &mcp.ResourceTemplate{
+		Name:        "video",
+		Title:       "YouTube video",
+		URITemplate: "youtube://video/{id}",
+		MIMEType:    jsonMIMEType,
+		Description: "A video's details and statistics. Reads return application/json, followed by the same content as text/markdown.",
+	}, readVideoResource)
+
+	addResourceTemplate(server, accounts, &mcp.ResourceTemplate{
+		Name:        "channel",
+		Title:       "YouTube channel",
+		URITemplate: "youtube://channel/{id}",
+		MIMEType:    jsonMIMEType,
+		Description: "A channel's details and statistics. Reads return application/json, followed by the same content as text/markdown.",
+	}, readChannelResource)
+
+	addResourceTemplate(server, accounts, &mcp.ResourceTemplate{
+		Name:        "playlist",
+		Title:       "YouTube playlist",
+		URITemplate: "youtube://playlist/{id}",
+		MIMEType:    jsonMIMEType,
+		Description: fmt.Sprintf("A playlist and its first %d items. Reads return application/json, followed by the same content as text/markdown.", playlistResourceItems),
+	}, readPlaylistResource)
+
+	if !accounts.ReadOnly() {
+		addResourceTemplate(server, accounts, &mcp.ResourceTemplate{
+			Name:        "transcript",
+			Title:       "YouTube video transcript",
+			URITemplate: "youtube://video/{id}/transcript",
+			MIMEType:    markdownMIMEType,
+			Description: "A video's transcript with timestamps. Reads return text/markdown, followed by the same transcript as application/json. YouTube only allows downloading captions of videos the authenticated account can edit.",
+		}, readTranscriptResource)
+	}
+}
+
+// addResourceTemplate registers a resource template whose {id} is passed to read.
+// Resources are always read with the default account.
+func addResourceTemplate(server *mcp.Server, accounts *AccountManager, template *mcp.ResourceTemplate, read resourceReader) {
+	pattern := uritemplate.MustNew(template.URITemplate)
+
+	server.AddResourceTemplate(template, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		id := pattern.Match(uri).Get("id").String()
+		if id == "" {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+
+		youtubeClient, err := accounts.Client("")
+		if err != nil {
+			return nil, err
+		}
+
+		data, markdown, err := read(youtubeClient, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", uri, err)
+		}
+		jsonText, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %v", uri, err)
+		}
+
+		contents := []*mcp.ResourceContents{
+			{URI: uri, MIMEType: jsonMIMEType, Text: string(jsonText)},
+			{URI: uri, MIMEType: markdownMIMEType, Text: markdown},
+		}
+		if template.MIMEType == markdownMIMEType {
+			contents[0], contents[1] = contents[1], contents[0]
+		}
+		return &mcp.ReadResourceResult{Contents: contents}, nil
+	})
+}
+
+// readVideoResource renders youtube://video/{id}
+func readVideoResource(youtubeClient *YouTubeClient, id string) (interface{}, string, error) {
+	video, err := youtubeClient.GetVideoDetails(id, []string{"status"}, "")
+	if err != nil {
+		return nil, "", err
+	}
+	info := videoDetails(youtubeClient, video)
+
+	var md strings.Builder
+	fmt.Fprintf(&md, "# %s\n\n", info["title"])
+	fmt.Fprintf(&md, "- URL: https://www.youtube.com/watch?v=%s\n", video.Id)
+	if video.Snippet != nil {
+		fmt.Fprintf(&md, "- Channel: %s (https://www.youtube.com/channel/%s)\n", video.Snippet.ChannelTitle, video.Snippet.ChannelId)
+		fmt.Fprintf(&md, "- Published: %s\n", video.Snippet.PublishedAt)
+		if category := youtubeClient.CategoryName(video.Snippet.CategoryId, ""); category != "" {
+			fmt.Fprintf(&md, "- Category: %s\n", category)
+		}
+	}
+	if video.ContentDetails != nil {
+		fmt.Fprintf(&md, "- Duration: %s\n", video.ContentDetails.Duration)
+	}
+	if video.Statistics != nil {
+		fmt.Fprintf(&md, "- Views: %d, likes: %d, comments: %d\n",
+			video.Statistics.ViewCount, video.Statistics.LikeCount, video.Statistics.CommentCount)
+	}
+	if video.Snippet != nil {
+		if len(video.Snippet.Tags) > 0 {
+			fmt.Fprintf(&md, "- Tags: %s\n", strings.Join(video.Snippet.Tags, ", "))
+		}
+		if video.Snippet.Description != "" {
+			fmt.Fprintf(&md, "\n## Description\n\n%s\n", video.Snippet.Description)
+		}
+	}
+
+	return info, md.String(), nil
+}
+
+// readChannelResource renders youtube://channel/{id}
+func readChannelResource(youtubeClient *YouTubeClient, id string) (interface{}, string, error) {
+	channel, err := youtubeClient.GetChannelInfo(id, []string{"topicDetails"})
+	if err != nil {
+		return nil, "", err
+	}
+	info := channelInfo(channel)
+
+	var md strings.Builder
+	fmt.Fprintf(&md, "# %s\n\n", info["title"])
+	fmt.Fprintf(&md, "- URL: https://www.youtube.com/channel/%s\n", channel.Id)
+	if channel.Snippet != nil {
+		if channel.Snippet.CustomUrl != "" {
+			fmt.Fprintf(&md, "- Handle: %s\n", channel.Snippet.CustomUrl)
+		}
+		fmt.Fprintf(&md, "- Created: %s\n", channel.Snippet.PublishedAt)
+		if channel.Snippet.Country != "" {
+			fmt.Fprintf(&md, "- Country: %s\n", channel.Snippet.Country)
+		}
+	}
+	if stats := channel.Statistics; stats != nil {
+		if stats.HiddenSubscriberCount {
+			md.WriteString("- Subscribers: hidden\n")
+		} else {
+			fmt.Fprintf(&md, "- Subscribers: %d\n", stats.SubscriberCount)
+		}
+		fmt.Fprintf(&md, "- Videos: %d, views: %d\n", stats.VideoCount, stats.ViewCount)
+	}
+	if channel.TopicDetails != nil && len(channel.TopicDetails.TopicCategories) > 0 {
+		fmt.Fprintf(&md, "- Topics: %s\n", strings.Join(topicNames(channel.TopicDetails.TopicCategories), ", "))
+	}
+	if channel.Snippet != nil && channel.Snippet.Description != "" {
+		fmt.Fprintf(&md, "\n## Description\n\n%s\n", channel.Snippet.Description)
+	}
+
+	return info, md.String(), nil
+}
+
+// readPlaylistResource renders youtube://playlist/{id}
+func readPlaylistResource(youtubeClient *YouTubeClient, id string) (interface{}, string, error) {
+	playlists, err := youtubeClient.GetPlaylists([]string{id})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(playlists) == 0 {
+		return nil, "", fmt.Errorf("playlist not found")
+	}
+	playlist := playlists[0]
+
+	items, err := youtubeClient.GetPlaylistItems(id, playlistResourceItems)
+	if err != nil {
+		return nil, "", err
+	}
+
+	info := playlistInfo(playlist)
+	var itemList []map[string]interface{}
+	for _, item := range items {
+		itemList = append(itemList, playlistItemInfo(item))
+	}
+	info["items"] = itemList
+
+	var md strings.Builder
+	fmt.Fprintf(&md, "# %s\n\n", info["title"])
+	fmt.Fprintf(&md, "- URL: https://www.youtube.com/playlist?list=%s\n", playlist.Id)
+	if playlist.Snippet != nil {
+		fmt.Fprintf(&md, "- Channel: %s (https://www.youtube.com/channel/%s)\n", playlist.Snippet.ChannelTitle, playlist.Snippet.ChannelId)
+	}
+	if playlist.ContentDetails != nil {
+		fmt.Fprintf(&md, "- Videos: %d\n", playlist.ContentDetails.ItemCount)
+	}
+	if playlist.Snippet != nil && playlist.Snippet.Description != "" {
+		fmt.Fprintf(&md, "\n## Description\n\n%s\n", playlist.Snippet.Description)
+	}
+	// Deleted and private videos have no details and are left out, so number
+	// only the videos listed
+	listed := 0
+	for _, item := range items {
+		if item.Snippet == nil || item.ContentDetails == nil {
+			continue
+		}
+		if listed == 0 {
+			md.WriteString("\n## Videos\n\n")
+		}
+		listed++
+		fmt.Fprintf(&md, "%d. [%s](https://www.youtube.com/watch?v=%s)\n", listed, item.Snippet.Title, item.ContentDetails.VideoId)
+	}
+
+	return info, md.String(), nil
+}
+
+// readTranscriptResource renders youtube://video/{id}/transcript from the
+// video's caption track, preferring a manually created track over automatic ones
+func readTranscriptResource(youtubeClient *YouTubeClient, id string) (interface{}, string, error) {
+	captions, err := youtubeClient.ListCaptions(id)
+	if err != nil {
+		return nil, "", fmt.Errorf("transcript unavailable (listing captions requires write access to be enabled): %v", err)
+	}
+
+	var track *youtube.Caption
+	for _, caption := range captions {
+		if caption.Snippet == nil || caption.Snippet.IsDraft || caption.Snippet.Status == "failed" {
+			continue
+		}
+		if track == nil || (track.Snippet.TrackKind == "asr" && caption.Snippet.TrackKind != "asr") {
+			track = caption
+		}
+	}
+	if track == nil {
+		return nil, "", fmt.Errorf("video has no published caption tracks")
+	}
+
+	data, err := youtubeClient.DownloadCaption(track.Id, "vtt")
+	if err != nil {
+		return nil, "", fmt.Errorf("transcript unavailable (YouTube only allows downloading captions of videos the authenticated account can edit): %v", err)
+	}
+	transcript := parseTranscript(data)
+
+	var lines []map[string]interface{}
+	var md strings.Builder
+	fmt.Fprintf(&md, "# Transcript of https://www.youtube.com/watch?v=%s\n\n", id)
+	fmt.Fprintf(&md, "Language: %s", track.Snippet.Language)
+	if track.Snippet.TrackKind == "asr" {
+		md.WriteString(" (automatic captions)")
+	}
+	md.WriteString("\n\n")
+	for _, line := range transcript {
+		fmt.Fprintf(&md, "[%s] %s\n", formatTimestamp(line.Start), line.Text)
+		lines = append(lines, map[string]interface{}{
+			"start_seconds": line.Start.Seconds(),
+			"text":          line.Text,
+		})
+	}
+
+	return map[string]interface{}{
+		"video_id":     id,
+		"caption_id":   track.Id,
+		"language":     track.Snippet.Language,
+		"is_automatic": track.Snippet.TrackKind == "asr",
+		"lines":        lines,
+	}, md.String(), nil
+}
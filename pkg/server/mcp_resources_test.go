@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestParseTranscript(t *testing.T) {
+	tests := []struct {
+		name string
+		vtt  string
+		want []TranscriptLine
+	}{
+		{
+			name: "empty",
+			vtt:  "WEBVTT\n",
+		},
+		{
+			name: "cues with identifiers, settings and markup",
+			vtt: "\ufeffWEBVTT\r\nKind: captions\r\n\r\nNOTE skip me\r\n\r\nintro\r\n00:00:01.500 --> 00:00:03.000 align:start\r\n<v Bob>Hello <b>there</b></v>\r\n\r\n" +
+				"01:02:03.000 --> 01:02:05.000\r\nSecond line\r\nand a third\r\n",
+			want: []TranscriptLine{
+				{Start: 1500 * time.Millisecond, Text: "Hello there"},
+				{Start: time.Hour + 2*time.Minute + 3*time.Second, Text: "Second line"},
+				{Start: time.Hour + 2*time.Minute + 3*time.Second, Text: "and a third"},
+			},
+		},
+		{
+			name: "rolling automatic captions repeat lines once",
+			vtt: "WEBVTT\n\n00:00:00.000 --> 00:00:02.000\nfirst words\n\n" +
+				"00:00:02.000 --> 00:00:04.000\nfirst words\nsecond words\n\n" +
+				"00:00:04.000 --> 00:00:06.000\nsecond words\n<00:00:04.500><c>third</c> words\n",
+			want: []TranscriptLine{
+				{Start: 0, Text: "first words"},
+				{Start: 2 * time.Second, Text: "second words"},
+				{Start: 4 * time.Second, Text: "third words"},
+			},
+		},
+		{
+			name: "short timestamps and blank cues",
+			vtt:  "WEBVTT\n\n00:05.000 --> 00:06.000\n   \n\n01:10.250 --> 01:12.000\nText\n",
+			want: []TranscriptLine{{Start: 70*time.Second + 250*time.Millisecond, Text: "Text"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTranscript([]byte(tt.vtt)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTranscript = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "00:00:00"},
+		{1500 * time.Millisecond, "00:00:01"},
+		{59*time.Minute + 59*time.Second, "00:59:59"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "01:02:03"},
+		{25 * time.Hour, "25:00:00"},
+	}
+	for _, tt := range tests {
+		if got := formatTimestamp(tt.d); got != tt.want {
+			t.Errorf("formatTimestamp(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestSetupResourcesTranscriptNeedsWriteAccess(t *testing.T) {
+	for _, readOnly := range []bool{true, false} {
+		ctx := context.Background()
+		server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+		setupResources(server, &AccountManager{config: &Config{ReadOnly: readOnly}})
+
+		serverTransport, clientTransport := mcp.NewInMemoryTransports()
+		if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+			t.Fatal(err)
+		}
+		session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var names []string
+		for template, err := range session.ResourceTemplates(ctx, nil) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, template.Name)
+		}
+		session.Close()
+
+		hasTranscript := false
+		for _, name := range names {
+			hasTranscript = hasTranscript || name == "transcript"
+		}
+		if len(names) < 3 || hasTranscript == readOnly {
+			t.Errorf("read_only %v registered templates %v", readOnly, names)
+		}
+	}
+}
+
+// fakePlaylistClient serves a playlist and its items
+func fakePlaylistClient(t *testing.T, items []*youtube.PlaylistItem) *YouTubeClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/playlistItems") {
+			json.NewEncoder(w).Encode(&youtube.PlaylistItemListResponse{Items: items})
+			return
+		}
+		json.NewEncoder(w).Encode(&youtube.PlaylistListResponse{Items: []*youtube.Playlist{{
+			Id:      "PL1",
+			Snippet: &youtube.PlaylistSnippet{Title: "Mix", ChannelTitle: "Channel", ChannelId: "UC1"},
+		}}})
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &Config{APIEndpoint: server.URL + "/"}
+	service, err := newService(context.Background(), cfg, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("newService: %v", err)
+	}
+	return &YouTubeClient{config: cfg, service: service}
+}
+
+func TestReadPlaylistResourceNumbersListedVideos(t *testing.T) {
+	video := func(id, title string) *youtube.PlaylistItem {
+		return &youtube.PlaylistItem{
+			Snippet:        &youtube.PlaylistItemSnippet{Title: title},
+			ContentDetails: &youtube.PlaylistItemContentDetails{VideoId: id},
+		}
+	}
+	tests := []struct {
+		name  string
+		items []*youtube.PlaylistItem
+		want  string
+	}{
+		{
+			name:  "deleted and private videos leave no gaps",
+			items: []*youtube.PlaylistItem{video("a", "First"), {Snippet: &youtube.PlaylistItemSnippet{Title: "Deleted video"}}, {}, video("b", "Second")},
+			want:  "## Videos\n\n1. [First](https://www.youtube.com/watch?v=a)\n2. [Second](https://www.youtube.com/watch?v=b)\n",
+		},
+		{
+			name:  "no listed videos, no section",
+			items: []*youtube.PlaylistItem{{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, md, err := readPlaylistResource(fakePlaylistClient(t, tt.items), "PL1")
+			if err != nil {
+				t.Fatalf("readPlaylistResource: %v", err)
+			}
+			_, videos, found := strings.Cut(md, "\n## Videos")
+			if tt.want == "" {
+				if found {
+					t.Errorf("markdown has a videos section:\n%s", md)
+				}
+				return
+			}
+			if got := "## Videos" + videos; got != tt.want {
+				t.Errorf("videos section = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
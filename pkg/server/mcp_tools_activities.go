@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// GetChannelActivitiesArgs represents arguments for getting channel activities
+type GetChannelActivitiesArgs struct {
+	ChannelID       string `json:"channel_id,omitempty"`
+	Mine            bool   `json:"mine,omitempty"`
+	PublishedAfter  string `json:"published_after,omitempty"`
+	PublishedBefore string `json:"published_before,omitempty"`
+	MaxResults      int64  `json:"max_results,omitempty"`
+	PageToken       string `json:"page_token,omitempty"`
+	Account         string `json:"account,omitempty"`
+}
+
+// addResourceID copies the IDs a resource reference points to into info
+func addResourceID(info map[string]interface{}, resource *youtube.ResourceId) {
+	if resource == nil {
+		return
+	}
+	if resource.VideoId != "" {
+		info["video_id"] = resource.VideoId
+	}
+	if resource.ChannelId != "" {
+		info["channel_id"] = resource.ChannelId
+	}
+	if resource.PlaylistId != "" {
+		info["playlist_id"] = resource.PlaylistId
+	}
+}
+
+// activityResource normalizes the resource an activity refers to, whatever its type
+func activityResource(details *youtube.ActivityContentDetails) map[string]interface{} {
+	resource := map[string]interface{}{}
+	if details == nil {
+		return resource
+	}
+
+	switch {
+	case details.Upload != nil:
+		resource["video_id"] = details.Upload.VideoId
+	case details.Like != nil:
+		addResourceID(resource, details.Like.ResourceId)
+	case details.Favorite != nil:
+		addResourceID(resource, details.Favorite.ResourceId)
+	case details.Comment != nil:
+		addResourceID(resource, details.Comment.ResourceId)
+	case details.Subscription != nil:
+		addResourceID(resource, details.Subscription.ResourceId)
+	case details.PlaylistItem != nil:
+		addResourceID(resource, details.PlaylistItem.ResourceId)
+		resource["playlist_id"] = details.PlaylistItem.PlaylistId
+		resource["playlist_item_id"] = details.PlaylistItem.PlaylistItemId
+	case details.Recommendation != nil:
+		addResourceID(resource, details.Recommendation.ResourceId)
+		resource["reason"] = details.Recommendation.Reason
+	case details.Bulletin != nil:
+		addResourceID(resource, details.Bulletin.ResourceId)
+	case details.Social != nil:
+		addResourceID(resource, details.Social.ResourceId)
+		resource["author"] = details.Social.Author
+	case details.ChannelItem != nil:
+		addResourceID(resource, details.ChannelItem.ResourceId)
+	case details.PromotedItem != nil:
+		resource["video_id"] = details.PromotedItem.VideoId
+	}
+
+	return resource
+}
+
+// setupActivityTools registers the channel activity tools
+func setupActivityTools(server *mcp.Server, accounts *AccountManager) {
+	// Get channel activities tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_channel_activities",
+		Description: "Get the activity feed of a channel (channel_id) or of the authenticated user (mine, requires OAuth2): uploads, likes, playlist additions, subscriptions and more. Each activity has its type and the IDs of the resource it refers to. Optional published_after/published_before (RFC 3339), max_results (default 10, max 50) and page_token.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetChannelActivitiesArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if (args.ChannelID == "") == !args.Mine {
+			return nil, nil, fmt.Errorf("exactly one of channel_id or mine must be given")
+		}
+		publishedAfter, err := parseOptionalTime("published_after", args.PublishedAfter)
+		if err != nil {
+			return nil, nil, err
+		}
+		publishedBefore, err := parseOptionalTime("published_before", args.PublishedBefore)
+		if err != nil {
+			return nil, nil, err
+		}
+		if args.MaxResults == 0 {
+			args.MaxResults = 10
+		}
+
+		response, err := youtubeClient.ListActivities(args.ChannelID, args.Mine, publishedAfter, publishedBefore, args.MaxResults, args.PageToken)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get channel activities: %v", err)
+		}
+
+		var activities []map[string]interface{}
+		for _, activity := range response.Items {
+			info := map[string]interface{}{
+				"activity_id": activity.Id,
+				"resource":    activityResource(activity.ContentDetails),
+			}
+			if activity.Snippet != nil {
+				info["type"] = activity.Snippet.Type
+				info["title"] = activity.Snippet.Title
+				info["description"] = activity.Snippet.Description
+				info["channel_id"] = activity.Snippet.ChannelId
+				info["channel_title"] = activity.Snippet.ChannelTitle
+				info["published_at"] = activity.Snippet.PublishedAt
+				info["thumbnail_url"] = thumbnailURL(activity.Snippet.Thumbnails)
+			}
+			activities = append(activities, info)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"activities":      activities,
+			"next_page_token": response.NextPageToken,
+		})
+	})
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// UploadCaptionArgs represents arguments for uploading a caption track
+type UploadCaptionArgs struct {
+	VideoID  string `json:"video_id"`
+	Language string `json:"language"`
+	Name     string `json:"name,omitempty"`
+	IsDraft  bool   `json:"is_draft,omitempty"`
+	FilePath string `json:"file_path"`
+	Account  string `json:"account,omitempty"`
+}
+
+// UpdateCaptionArgs represents arguments for updating a caption track
+type UpdateCaptionArgs struct {
+	CaptionID string `json:"caption_id"`
+	IsDraft   *bool  `json:"is_draft,omitempty"`
+	FilePath  string `json:"file_path,omitempty"`
+	Account   string `json:"account,omitempty"`
+}
+
+// DeleteCaptionArgs represents arguments for deleting a caption track
+type DeleteCaptionArgs struct {
+	CaptionID string `json:"caption_id"`
+	Account   string `json:"account,omitempty"`
+}
+
+// readCaptionFile reads an SRT or WebVTT file and checks its syntax
+func readCaptionFile(path string) ([]byte, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading caption file: %v", err)
+	}
+
+	format, err := validateCaptionFile(path, data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, format, nil
+}
+
+// captionInfo converts a caption track into tool output
+func captionInfo(caption *youtube.Caption) map[string]interface{} {
+	info := map[string]interface{}{
+		"caption_id": caption.Id,
+	}
+	if caption.Snippet != nil {
+		info["video_id"] = caption.Snippet.VideoId
+		info["language"] = caption.Snippet.Language
+		info["name"] = caption.Snippet.Name
+		info["is_draft"] = caption.Snippet.IsDraft
+		info["track_kind"] = caption.Snippet.TrackKind
+		info["status"] = caption.Snippet.Status
+		info["last_updated"] = caption.Snippet.LastUpdated
+	}
+	return info
+}
+
+// setupCaptionWriteTools registers the tools that upload and manage caption tracks
+func setupCaptionWriteTools(server *mcp.Server, accounts *AccountManager) {
+	// Upload caption tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "upload_caption",
+		Description: "Upload a caption track for a video from a local SRT or WebVTT file. Accepts video_id, language (BCP-47, e.g. en or pt-BR), optional name and is_draft. The file syntax is checked locally first and parse errors are reported by line. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UploadCaptionArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data, format, err := readCaptionFile(args.FilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		caption, err := youtubeClient.UploadCaption(args.VideoID, args.Language, args.Name, args.IsDraft, data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to upload caption: %v", err)
+		}
+
+		info := captionInfo(caption)
+		info["format"] = format
+		return jsonResult(info)
+	})
+
+	// Update caption tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_caption",
+		Description: "Update a caption track: change is_draft and/or replace its content from a local SRT or WebVTT file (checked locally first). Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UpdateCaptionArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.IsDraft == nil && args.FilePath == "" {
+			return nil, nil, fmt.Errorf("nothing to update: give is_draft and/or file_path")
+		}
+
+		var data []byte
+		if args.FilePath != "" {
+			data, _, err = readCaptionFile(args.FilePath)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		caption, err := youtubeClient.UpdateCaption(args.CaptionID, args.IsDraft, data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to update caption: %v", err)
+		}
+
+		return jsonResult(captionInfo(caption))
+	})
+
+	// Delete caption tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_caption",
+		Description: "Delete a caption track. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args DeleteCaptionArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := youtubeClient.DeleteCaption(args.CaptionID); err != nil {
+			return nil, nil, fmt.Errorf("failed to delete caption: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"caption_id": args.CaptionID,
+			"deleted":    true,
+		})
+	})
+}
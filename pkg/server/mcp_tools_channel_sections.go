@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// GetChannelSectionsArgs represents arguments for getting channel sections
+type GetChannelSectionsArgs struct {
+	ChannelID string `json:"channel_id,omitempty"`
+	Mine      bool   `json:"mine,omitempty"`
+	Expand    bool   `json:"expand,omitempty"`
+	Account   string `json:"account,omitempty"`
+}
+
+// setupChannelSectionTools registers the channel section tools
+func setupChannelSectionTools(server *mcp.Server, accounts *AccountManager) {
+	// Get channel sections tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_channel_sections",
+		Description: "Get the sections of a channel page (channel_id, or mine with OAuth2) in display order: each section's type (e.g. singlePlaylist, multiplePlaylists, multipleChannels, recentUploads), title, position and the playlist and channel IDs it features. With expand, the referenced playlists and channels are resolved into titles in the same call.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetChannelSectionsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if (args.ChannelID == "") == !args.Mine {
+			return nil, nil, fmt.Errorf("exactly one of channel_id or mine must be given")
+		}
+
+		sections, err := youtubeClient.ListChannelSections(args.ChannelID, args.Mine)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get channel sections: %v", err)
+		}
+		sort.SliceStable(sections, func(i, j int) bool {
+			return sectionPosition(sections[i]) < sectionPosition(sections[j])
+		})
+
+		// Collect the referenced IDs so they can be expanded in one batch each
+		var playlistIDs, channelIDs []string
+		for _, section := range sections {
+			if section.ContentDetails != nil {
+				playlistIDs = append(playlistIDs, section.ContentDetails.Playlists...)
+				channelIDs = append(channelIDs, section.ContentDetails.Channels...)
+			}
+		}
+
+		playlistTitles := map[string]string{}
+		channelTitles := map[string]string{}
+		if args.Expand {
+			playlists, err := youtubeClient.GetPlaylists(playlistIDs)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to expand playlists: %v", err)
+			}
+			for _, playlist := range playlists {
+				if playlist.Snippet != nil {
+					playlistTitles[playlist.Id] = playlist.Snippet.Title
+				}
+			}
+
+			channels, err := youtubeClient.GetChannels(channelIDs)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to expand channels: %v", err)
+			}
+			for _, channel := range channels {
+				if channel.Snippet != nil {
+					channelTitles[channel.Id] = channel.Snippet.Title
+				}
+			}
+		}
+
+		var sectionList []map[string]interface{}
+		for _, section := range sections {
+			info := map[string]interface{}{
+				"section_id": section.Id,
+			}
+			if section.Snippet != nil {
+				info["type"] = section.Snippet.Type
+				info["title"] = section.Snippet.Title
+				if section.Snippet.Position != nil {
+					info["position"] = *section.Snippet.Position
+				}
+			}
+			if section.ContentDetails != nil {
+				if args.Expand {
+					info["playlists"] = expandIDs(section.ContentDetails.Playlists, "playlist_id", playlistTitles)
+					info["channels"] = expandIDs(section.ContentDetails.Channels, "channel_id", channelTitles)
+				} else {
+					info["playlist_ids"] = section.ContentDetails.Playlists
+					info["channel_ids"] = section.ContentDetails.Channels
+				}
+			}
+			sectionList = append(sectionList, info)
+		}
+
+		return jsonResult(sectionList)
+	})
+}
+
+// sectionPosition returns the display position of a section, placing sections without one last
+func sectionPosition(section *youtube.ChannelSection) int64 {
+	if section.Snippet == nil || section.Snippet.Position == nil {
+		return math.MaxInt64
+	}
+	return *section.Snippet.Position
+}
+
+// expandIDs pairs each ID with its resolved title
+func expandIDs(ids []string, key string, titles map[string]string) []map[string]interface{} {
+	var expanded []map[string]interface{}
+	for _, id := range ids {
+		expanded = append(expanded, map[string]interface{}{
+			key:     id,
+			"title": titles[id],
+		})
+	}
+	return expanded
+}
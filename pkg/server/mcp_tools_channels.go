@@ -0,0 +1,111 @@
+package server
+
+import (
+	"google.golang.org/api/youtube/v3"
+)
+
+// channelParts are the optional channel resource parts get_channel_info can request
+var channelParts = []string{"brandingSettings", "topicDetails", "status", "localizations", "contentOwnerDetails"}
+
+// channelInfo converts a channel into tool output, including whichever optional parts were fetched
+func channelInfo(channel *youtube.Channel) map[string]interface{} {
+	info := map[string]interface{}{
+		"channel_id": channel.Id,
+	}
+
+	if snippet := channel.Snippet; snippet != nil {
+		info["title"] = snippet.Title
+		info["description"] = snippet.Description
+		info["custom_url"] = snippet.CustomUrl
+		info["published_at"] = snippet.PublishedAt
+		info["country"] = snippet.Country
+		info["thumbnail_url"] = thumbnailURL(snippet.Thumbnails)
+		if snippet.DefaultLanguage != "" {
+			info["default_language"] = snippet.DefaultLanguage
+		}
+		if localized := snippet.Localized; localized != nil && localized.Title != snippet.Title {
+			info["localized_title"] = localized.Title
+			info["localized_description"] = localized.Description
+		}
+	}
+
+	if stats := channel.Statistics; stats != nil {
+		info["subscriber_count"] = stats.SubscriberCount
+		info["video_count"] = stats.VideoCount
+		info["view_count"] = stats.ViewCount
+		info["hidden_subscriber_count"] = stats.HiddenSubscriberCount
+	}
+
+	if channel.ContentDetails != nil && channel.ContentDetails.RelatedPlaylists != nil {
+		related := channel.ContentDetails.RelatedPlaylists
+		info["uploads_playlist_id"] = related.Uploads
+		if related.Likes != "" {
+			info["likes_playlist_id"] = related.Likes
+		}
+		playlists := map[string]string{}
+		for name, id := range map[string]string{
+			"uploads":       related.Uploads,
+			"likes":         related.Likes,
+			"favorites":     related.Favorites,
+			"watch_history": related.WatchHistory,
+			"watch_later":   related.WatchLater,
+		} {
+			if id != "" {
+				playlists[name] = id
+			}
+		}
+		info["related_playlists"] = playlists
+	}
+
+	if branding := channel.BrandingSettings; branding != nil {
+		brandingInfo := map[string]interface{}{}
+		if settings := branding.Channel; settings != nil {
+			brandingInfo["title"] = settings.Title
+			brandingInfo["description"] = settings.Description
+			brandingInfo["keywords"] = settings.Keywords
+			brandingInfo["country"] = settings.Country
+			brandingInfo["default_language"] = settings.DefaultLanguage
+			brandingInfo["unsubscribed_trailer"] = settings.UnsubscribedTrailer
+		}
+		if branding.Image != nil {
+			brandingInfo["banner_url"] = branding.Image.BannerExternalUrl
+		}
+		info["branding"] = brandingInfo
+	}
+
+	if topics := channel.TopicDetails; topics != nil {
+		info["topics"] = topicNames(topics.TopicCategories)
+		info["topic_urls"] = topics.TopicCategories
+		info["topic_ids"] = topics.TopicIds
+	}
+
+	if status := channel.Status; status != nil {
+		info["status"] = map[string]interface{}{
+			"privacy_status":              status.PrivacyStatus,
+			"is_linked":                   status.IsLinked,
+			"long_uploads_status":         status.LongUploadsStatus,
+			"made_for_kids":               status.MadeForKids,
+			"self_declared_made_for_kids": status.SelfDeclaredMadeForKids,
+		}
+	}
+
+	if channel.Localizations != nil {
+		localizations := map[string]interface{}{}
+		for language, localization := range channel.Localizations {
+			localizations[language] = map[string]interface{}{
+				"title":       localization.Title,
+				"description": localization.Description,
+			}
+		}
+		info["localizations"] = localizations
+	}
+
+	if owner := channel.ContentOwnerDetails; owner != nil {
+		info["content_owner"] = map[string]interface{}{
+			"content_owner": owner.ContentOwner,
+			"time_linked":   owner.TimeLinked,
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,224 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// BanChatUserArgs represents arguments for banning a user from a live chat
+type BanChatUserArgs struct {
+	VideoID         string `json:"video_id,omitempty"`
+	LiveChatID      string `json:"live_chat_id,omitempty"`
+	ChannelID       string `json:"channel_id"`
+	DurationSeconds uint64 `json:"duration_seconds,omitempty"`
+	Account         string `json:"account,omitempty"`
+}
+
+// UnbanChatUserArgs represents arguments for lifting a live chat ban
+type UnbanChatUserArgs struct {
+	BanID   string `json:"ban_id"`
+	Account string `json:"account,omitempty"`
+}
+
+// AddChatModeratorArgs represents arguments for adding a live chat moderator
+type AddChatModeratorArgs struct {
+	VideoID    string `json:"video_id,omitempty"`
+	LiveChatID string `json:"live_chat_id,omitempty"`
+	ChannelID  string `json:"channel_id"`
+	Account    string `json:"account,omitempty"`
+}
+
+// RemoveChatModeratorArgs represents arguments for removing a live chat moderator
+type RemoveChatModeratorArgs struct {
+	ModeratorID string `json:"moderator_id,omitempty"`
+	VideoID     string `json:"video_id,omitempty"`
+	LiveChatID  string `json:"live_chat_id,omitempty"`
+	ChannelID   string `json:"channel_id,omitempty"`
+	Account     string `json:"account,omitempty"`
+}
+
+// ListChatModeratorsArgs represents arguments for listing live chat moderators
+type ListChatModeratorsArgs struct {
+	VideoID    string `json:"video_id,omitempty"`
+	LiveChatID string `json:"live_chat_id,omitempty"`
+	MaxResults int64  `json:"max_results,omitempty"`
+	PageToken  string `json:"page_token,omitempty"`
+	Account    string `json:"account,omitempty"`
+}
+
+// chatModeratorInfo converts a live chat moderator into tool output
+func chatModeratorInfo(moderator *youtube.LiveChatModerator) map[string]interface{} {
+	info := map[string]interface{}{
+		"moderator_id": moderator.Id,
+	}
+	if moderator.Snippet != nil {
+		info["live_chat_id"] = moderator.Snippet.LiveChatId
+		if details := moderator.Snippet.ModeratorDetails; details != nil {
+			info["channel_id"] = details.ChannelId
+			info["display_name"] = details.DisplayName
+			info["channel_url"] = details.ChannelUrl
+		}
+	}
+	return info
+}
+
+// setupChatModerationTools registers the tools that list and manage moderators
+// and ban viewers
+func setupChatModerationTools(server *mcp.Server, accounts *AccountManager) {
+	// List chat moderators tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_chat_moderators",
+		Description: "List the moderators of a live chat owned by the authenticated user, given a video_id or live_chat_id. Optional max_results (default 10) and page_token. Requires OAuth2 with write access and chat moderation tools enabled.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListChatModeratorsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.MaxResults == 0 {
+			args.MaxResults = 10
+		}
+
+		liveChatID, err := resolveLiveChatID(youtubeClient, args.VideoID, args.LiveChatID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list chat moderators: %v", err)
+		}
+
+		response, err := youtubeClient.ListChatModerators(liveChatID, args.MaxResults, args.PageToken)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list chat moderators: %v", err)
+		}
+
+		var moderators []map[string]interface{}
+		for _, moderator := range response.Items {
+			moderators = append(moderators, chatModeratorInfo(moderator))
+		}
+
+		return jsonResult(map[string]interface{}{
+			"live_chat_id":    liveChatID,
+			"moderators":      moderators,
+			"next_page_token": response.NextPageToken,
+			"total_results":   totalResults(response.PageInfo),
+		})
+	})
+
+	// Ban chat user tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "ban_chat_user",
+		Description: "Ban a viewer (channel_id) from the live chat of a video (video_id) or a live chat (live_chat_id). Temporary for duration_seconds when given, otherwise permanent. Returns the ban_id needed to unban. Requires OAuth2 with write access and chat moderation tools enabled.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args BanChatUserArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.ChannelID == "" {
+			return nil, nil, fmt.Errorf("channel_id must be given")
+		}
+
+		liveChatID, err := resolveLiveChatID(youtubeClient, args.VideoID, args.LiveChatID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to ban chat user: %v", err)
+		}
+
+		ban, err := youtubeClient.BanChatUser(liveChatID, args.ChannelID, args.DurationSeconds)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to ban chat user: %v", err)
+		}
+
+		result := map[string]interface{}{
+			"ban_id":       ban.Id,
+			"live_chat_id": liveChatID,
+			"channel_id":   args.ChannelID,
+		}
+		if ban.Snippet != nil {
+			result["type"] = ban.Snippet.Type
+			result["duration_seconds"] = ban.Snippet.BanDurationSeconds
+		}
+		return jsonResult(result)
+	})
+
+	// Unban chat user tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "unban_chat_user",
+		Description: "Lift a live chat ban by the ban_id returned from ban_chat_user. Requires OAuth2 with write access and chat moderation tools enabled.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UnbanChatUserArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := youtubeClient.UnbanChatUser(args.BanID); err != nil {
+			return nil, nil, fmt.Errorf("failed to unban chat user: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"ban_id":   args.BanID,
+			"unbanned": true,
+		})
+	})
+
+	// Add chat moderator tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_chat_moderator",
+		Description: "Make a channel (channel_id) a moderator of the live chat of a video (video_id) or a live chat (live_chat_id). Requires OAuth2 with write access and chat moderation tools enabled.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args AddChatModeratorArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.ChannelID == "" {
+			return nil, nil, fmt.Errorf("channel_id must be given")
+		}
+
+		liveChatID, err := resolveLiveChatID(youtubeClient, args.VideoID, args.LiveChatID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to add chat moderator: %v", err)
+		}
+
+		moderator, err := youtubeClient.AddChatModerator(liveChatID, args.ChannelID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to add chat moderator: %v", err)
+		}
+
+		return jsonResult(chatModeratorInfo(moderator))
+	})
+
+	// Remove chat moderator tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "remove_chat_moderator",
+		Description: "Remove a live chat moderator, identified by moderator_id (from list_chat_moderators) or by channel_id together with a video_id or live_chat_id. Requires OAuth2 with write access and chat moderation tools enabled.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args RemoveChatModeratorArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if (args.ModeratorID == "") == (args.ChannelID == "") {
+			return nil, nil, fmt.Errorf("exactly one of moderator_id or channel_id must be given")
+		}
+		if args.ModeratorID == "" {
+			liveChatID, err := resolveLiveChatID(youtubeClient, args.VideoID, args.LiveChatID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to remove chat moderator: %v", err)
+			}
+			args.ModeratorID, err = youtubeClient.FindChatModeratorID(liveChatID, args.ChannelID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to remove chat moderator: %v", err)
+			}
+		}
+
+		if err := youtubeClient.RemoveChatModerator(args.ModeratorID); err != nil {
+			return nil, nil, fmt.Errorf("failed to remove chat moderator: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"moderator_id": args.ModeratorID,
+			"removed":      true,
+		})
+	})
+}
@@ -0,0 +1,208 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// PostCommentArgs represents arguments for posting a comment
+type PostCommentArgs struct {
+	VideoID string `json:"video_id"`
+	Text    string `json:"text"`
+	Account string `json:"account,omitempty"`
+}
+
+// ReplyToCommentArgs represents arguments for replying to a comment
+type ReplyToCommentArgs struct {
+	ParentID string `json:"parent_id"`
+	Text     string `json:"text"`
+	Account  string `json:"account,omitempty"`
+}
+
+// UpdateCommentArgs represents arguments for updating a comment
+type UpdateCommentArgs struct {
+	CommentID string `json:"comment_id"`
+	Text      string `json:"text"`
+	Account   string `json:"account,omitempty"`
+}
+
+// DeleteCommentArgs represents arguments for deleting a comment
+type DeleteCommentArgs struct {
+	CommentID string `json:"comment_id"`
+	Account   string `json:"account,omitempty"`
+}
+
+// SetCommentModerationStatusArgs represents arguments for moderating comments
+type SetCommentModerationStatusArgs struct {
+	CommentIDs       []string `json:"comment_ids"`
+	ModerationStatus string   `json:"moderation_status"`
+	BanAuthor        bool     `json:"ban_author,omitempty"`
+	Account          string   `json:"account,omitempty"`
+}
+
+// MarkCommentAsSpamArgs represents arguments for flagging comments as spam
+type MarkCommentAsSpamArgs struct {
+	CommentIDs []string `json:"comment_ids"`
+	Account    string   `json:"account,omitempty"`
+}
+
+// commentInfo converts a comment into tool output
+func commentInfo(comment *youtube.Comment) map[string]interface{} {
+	info := map[string]interface{}{
+		"comment_id": comment.Id,
+	}
+	if comment.Snippet != nil {
+		info["text"] = comment.Snippet.TextOriginal
+		info["author"] = comment.Snippet.AuthorDisplayName
+		info["author_channel_url"] = comment.Snippet.AuthorChannelUrl
+		info["video_id"] = comment.Snippet.VideoId
+		info["parent_id"] = comment.Snippet.ParentId
+		info["published_at"] = comment.Snippet.PublishedAt
+		info["updated_at"] = comment.Snippet.UpdatedAt
+		info["moderation_status"] = comment.Snippet.ModerationStatus
+	}
+	return info
+}
+
+// setupCommentTools registers the tools that post and moderate comments
+func setupCommentTools(server *mcp.Server, accounts *AccountManager) {
+	// Post comment tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "post_comment",
+		Description: "Post a new top-level comment on a video. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args PostCommentArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		thread, err := youtubeClient.PostComment(args.VideoID, args.Text)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to post comment: %v", err)
+		}
+
+		info := map[string]interface{}{"thread_id": thread.Id}
+		if thread.Snippet != nil && thread.Snippet.TopLevelComment != nil {
+			info = commentInfo(thread.Snippet.TopLevelComment)
+			info["thread_id"] = thread.Id
+		}
+
+		return jsonResult(info)
+	})
+
+	// Reply to comment tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "reply_to_comment",
+		Description: "Reply to a top-level comment, identified by parent_id. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ReplyToCommentArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		comment, err := youtubeClient.ReplyToComment(args.ParentID, args.Text)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to reply to comment: %v", err)
+		}
+
+		return jsonResult(commentInfo(comment))
+	})
+
+	// Update comment tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_comment",
+		Description: "Replace the text of one of the authenticated user's comments. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UpdateCommentArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		comment, err := youtubeClient.UpdateComment(args.CommentID, args.Text)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to update comment: %v", err)
+		}
+
+		return jsonResult(commentInfo(comment))
+	})
+
+	// Delete comment tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_comment",
+		Description: "Delete a comment. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args DeleteCommentArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := youtubeClient.DeleteComment(args.CommentID); err != nil {
+			return nil, nil, fmt.Errorf("failed to delete comment: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"comment_id": args.CommentID,
+			"deleted":    true,
+		})
+	})
+
+	// Set comment moderation status tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "set_comment_moderation_status",
+		Description: "Set the moderation status of comments on the authenticated user's channel: published, heldForReview or rejected. With ban_author (only allowed when rejecting) the authors are also banned from the channel. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args SetCommentModerationStatusArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch args.ModerationStatus {
+		case "published", "heldForReview", "rejected":
+		default:
+			return nil, nil, fmt.Errorf("invalid moderation_status %q (expected published, heldForReview or rejected)", args.ModerationStatus)
+		}
+		if args.BanAuthor && args.ModerationStatus != "rejected" {
+			return nil, nil, fmt.Errorf("ban_author can only be used with moderation_status rejected")
+		}
+		if len(args.CommentIDs) == 0 {
+			return nil, nil, fmt.Errorf("comment_ids must not be empty")
+		}
+
+		if err := youtubeClient.SetCommentModerationStatus(args.CommentIDs, args.ModerationStatus, args.BanAuthor); err != nil {
+			return nil, nil, fmt.Errorf("failed to set comment moderation status: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"comment_ids":       args.CommentIDs,
+			"moderation_status": args.ModerationStatus,
+			"author_banned":     args.BanAuthor,
+		})
+	})
+
+	// Mark comment as spam tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "mark_comment_as_spam",
+		Description: "Flag comments as spam. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args MarkCommentAsSpamArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(args.CommentIDs) == 0 {
+			return nil, nil, fmt.Errorf("comment_ids must not be empty")
+		}
+
+		if err := youtubeClient.MarkCommentAsSpam(args.CommentIDs); err != nil {
+			return nil, nil, fmt.Errorf("failed to mark comment as spam: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"comment_ids":    args.CommentIDs,
+			"marked_as_spam": true,
+		})
+	})
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// jsonResult marshals v as indented JSON into a text tool result
+func jsonResult(v interface{}) (*mcp.CallToolResult, any, error) {
+	response, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal response: %v", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(response)}},
+	}, nil, nil
+}
+
+// thumbnailURL returns the medium thumbnail URL, falling back to the default one
+func thumbnailURL(thumbnails *youtube.ThumbnailDetails) string {
+	if thumbnails == nil {
+		return ""
+	}
+	if thumbnails.Medium != nil {
+		return thumbnails.Medium.Url
+	}
+	if thumbnails.Default != nil {
+		return thumbnails.Default.Url
+	}
+	return ""
+}
+
+// totalResults returns the total result count reported by a list response
+func totalResults(pageInfo *youtube.PageInfo) int64 {
+	if pageInfo == nil {
+		return 0
+	}
+	return pageInfo.TotalResults
+}
+
+// parseOptionalTime parses an RFC 3339 timestamp argument, returning the zero time if empty
+func parseOptionalTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: expected an RFC 3339 timestamp such as 2024-01-31T00:00:00Z", name, value)
+	}
+	return t, nil
+}
+
+// progressNotifier returns a function that reports progress to the client, or
+// nil if the client did not ask for progress notifications
+func progressNotifier(ctx context.Context, req *mcp.CallToolRequest, message string) func(current, total int64) {
+	token := req.Params.GetProgressToken()
+	if token == nil || req.Session == nil {
+		return nil
+	}
+
+	return func(current, total int64) {
+		err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      float64(current),
+			Total:         float64(total),
+			Message:       message,
+		})
+		if err != nil {
+			log.Printf("Failed to send progress notification: %v", err)
+		}
+	}
+}
+
+// validateParts checks that every requested resource part is one of the supported ones
+func validateParts(parts, supported []string) error {
+	for _, part := range parts {
+		if !slices.Contains(supported, part) {
+			return fmt.Errorf("unsupported part %q (expected one of %s)", part, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
+
+// topicNames turns Wikipedia topic category URLs into readable names, e.g.
+// https://en.wikipedia.org/wiki/Role-playing_video_game becomes "Role-playing video game"
+func topicNames(topicURLs []string) []string {
+	var names []string
+	for _, topicURL := range topicURLs {
+		name := path.Base(topicURL)
+		if unescaped, err := url.PathUnescape(name); err == nil {
+			name = unescaped
+		}
+		names = append(names, strings.ReplaceAll(name, "_", " "))
+	}
+	return names
+}
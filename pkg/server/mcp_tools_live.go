@@ -0,0 +1,524 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// ListLiveBroadcastsArgs represents arguments for listing live broadcasts
+type ListLiveBroadcastsArgs struct {
+	BroadcastIDs     []string `json:"broadcast_ids,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	IncludeStreams   bool     `json:"include_streams,omitempty"`
+	RevealStreamKeys bool     `json:"reveal_stream_keys,omitempty"`
+	MaxResults       int64    `json:"max_results,omitempty"`
+	PageToken        string   `json:"page_token,omitempty"`
+	Account          string   `json:"account,omitempty"`
+}
+
+// ListLiveStreamsArgs represents arguments for listing live streams
+type ListLiveStreamsArgs struct {
+	StreamIDs        []string `json:"stream_ids,omitempty"`
+	RevealStreamKeys bool     `json:"reveal_stream_keys,omitempty"`
+	MaxResults       int64    `json:"max_results,omitempty"`
+	PageToken        string   `json:"page_token,omitempty"`
+	Account          string   `json:"account,omitempty"`
+}
+
+// CreateLiveBroadcastArgs represents arguments for scheduling a live broadcast
+type CreateLiveBroadcastArgs struct {
+	Title               string `json:"title"`
+	Description         string `json:"description,omitempty"`
+	ScheduledStartTime  string `json:"scheduled_start_time"`
+	ScheduledEndTime    string `json:"scheduled_end_time,omitempty"`
+	PrivacyStatus       string `json:"privacy_status,omitempty"`
+	MadeForKids         bool   `json:"made_for_kids,omitempty"`
+	EnableAutoStart     bool   `json:"enable_auto_start,omitempty"`
+	EnableAutoStop      bool   `json:"enable_auto_stop,omitempty"`
+	EnableDvr           bool   `json:"enable_dvr,omitempty"`
+	EnableMonitorStream *bool  `json:"enable_monitor_stream,omitempty"`
+	LatencyPreference   string `json:"latency_preference,omitempty"`
+	StreamID            string `json:"stream_id,omitempty"`
+	Account             string `json:"account,omitempty"`
+}
+
+// BindBroadcastStreamArgs represents arguments for binding a stream to a broadcast
+type BindBroadcastStreamArgs struct {
+	BroadcastID string `json:"broadcast_id"`
+	StreamID    string `json:"stream_id,omitempty"`
+	Account     string `json:"account,omitempty"`
+}
+
+// TransitionBroadcastArgs represents arguments for changing a broadcast's lifecycle state
+type TransitionBroadcastArgs struct {
+	BroadcastID        string `json:"broadcast_id"`
+	Status             string `json:"status"`
+	IgnoreStreamHealth bool   `json:"ignore_stream_health,omitempty"`
+	Account            string `json:"account,omitempty"`
+}
+
+// UpdateBroadcastArgs represents arguments for updating a live broadcast
+type UpdateBroadcastArgs struct {
+	BroadcastID        string  `json:"broadcast_id"`
+	Title              *string `json:"title,omitempty"`
+	Description        *string `json:"description,omitempty"`
+	ScheduledStartTime *string `json:"scheduled_start_time,omitempty"`
+	ScheduledEndTime   *string `json:"scheduled_end_time,omitempty"`
+	PrivacyStatus      *string `json:"privacy_status,omitempty"`
+	EnableAutoStart    *bool   `json:"enable_auto_start,omitempty"`
+	EnableAutoStop     *bool   `json:"enable_auto_stop,omitempty"`
+	EnableDvr          *bool   `json:"enable_dvr,omitempty"`
+	Account            string  `json:"account,omitempty"`
+}
+
+// broadcastTransitions lists the lifecycle states each transition can be made from
+var broadcastTransitions = map[string][]string{
+	"testing":  {"ready"},
+	"live":     {"ready", "testing"},
+	"complete": {"live"},
+}
+
+// checkBroadcastTransition explains why a broadcast cannot move to the target
+// state, or returns nil if the transition is valid from its lifecycle state
+func checkBroadcastTransition(broadcast *youtube.LiveBroadcast, target string) error {
+	from, ok := broadcastTransitions[target]
+	if !ok {
+		return fmt.Errorf("invalid status %q (expected testing, live or complete)", target)
+	}
+
+	current := ""
+	if broadcast.Status != nil {
+		current = broadcast.Status.LifeCycleStatus
+	}
+	if current == target {
+		return fmt.Errorf("broadcast is already %s", current)
+	}
+	if !slices.Contains(from, current) {
+		return fmt.Errorf("cannot transition a broadcast from %q to %q: it must be %s first",
+			current, target, strings.Join(from, " or "))
+	}
+
+	monitored := true
+	if details := broadcast.ContentDetails; details != nil && details.MonitorStream != nil && details.MonitorStream.EnableMonitorStream != nil {
+		monitored = *details.MonitorStream.EnableMonitorStream
+	}
+	switch {
+	case target == "testing" && !monitored:
+		return fmt.Errorf("cannot transition to testing: the broadcast's monitor stream is disabled, so it can go live directly")
+	case target == "live" && current == "ready" && monitored:
+		return fmt.Errorf("cannot transition from ready to live: the broadcast's monitor stream is enabled, so it must be transitioned to testing first")
+	}
+
+	return nil
+}
+
+// checkStreamHealth explains why a stream is not fit to go into testing or
+// live, or returns nil if it is receiving data in good or ok health
+func checkStreamHealth(stream *youtube.LiveStream) error {
+	if stream.Status == nil {
+		return fmt.Errorf("stream %s has no status", stream.Id)
+	}
+	if stream.Status.StreamStatus != "active" {
+		return fmt.Errorf("stream %s is %q, not active: start sending video to it first", stream.Id, stream.Status.StreamStatus)
+	}
+
+	health := stream.Status.HealthStatus
+	if health == nil || (health.Status != "good" && health.Status != "ok") {
+		status := "unknown"
+		var issues []string
+		if health != nil {
+			status = health.Status
+			for _, issue := range health.ConfigurationIssues {
+				issues = append(issues, issue.Description)
+			}
+		}
+		msg := fmt.Sprintf("stream %s health is %q", stream.Id, status)
+		if len(issues) > 0 {
+			msg += ": " + strings.Join(issues, "; ")
+		}
+		return fmt.Errorf("%s (set ignore_stream_health to transition anyway)", msg)
+	}
+
+	return nil
+}
+
+// validLatencyPreference checks a latency preference argument
+func validLatencyPreference(latency string) error {
+	switch latency {
+	case "", "normal", "low", "ultraLow":
+		return nil
+	}
+	return fmt.Errorf("invalid latency_preference %q (expected normal, low or ultraLow)", latency)
+}
+
+// validBroadcastTime checks that a broadcast time argument is an RFC 3339 timestamp
+func validBroadcastTime(name string, value *string) error {
+	if value == nil {
+		return nil
+	}
+	_, err := parseOptionalTime(name, *value)
+	return err
+}
+
+// broadcastInfo converts a live broadcast into tool output
+func broadcastInfo(broadcast *youtube.LiveBroadcast) map[string]interface{} {
+	info := map[string]interface{}{
+		"broadcast_id": broadcast.Id,
+	}
+	if snippet := broadcast.Snippet; snippet != nil {
+		info["title"] = snippet.Title
+		info["description"] = snippet.Description
+		info["channel_id"] = snippet.ChannelId
+		info["scheduled_start_time"] = snippet.ScheduledStartTime
+		info["scheduled_end_time"] = snippet.ScheduledEndTime
+		info["actual_start_time"] = snippet.ActualStartTime
+		info["actual_end_time"] = snippet.ActualEndTime
+		info["live_chat_id"] = snippet.LiveChatId
+		info["thumbnail_url"] = thumbnailURL(snippet.Thumbnails)
+	}
+	if status := broadcast.Status; status != nil {
+		info["life_cycle_status"] = status.LifeCycleStatus
+		info["privacy_status"] = status.PrivacyStatus
+		info["recording_status"] = status.RecordingStatus
+		info["made_for_kids"] = status.MadeForKids
+	}
+	if details := broadcast.ContentDetails; details != nil {
+		info["bound_stream_id"] = details.BoundStreamId
+		info["enable_auto_start"] = details.EnableAutoStart
+		info["enable_auto_stop"] = details.EnableAutoStop
+		info["enable_dvr"] = details.EnableDvr
+		info["latency_preference"] = details.LatencyPreference
+		if details.MonitorStream != nil && details.MonitorStream.EnableMonitorStream != nil {
+			info["monitor_stream_enabled"] = *details.MonitorStream.EnableMonitorStream
+		}
+	}
+	return info
+}
+
+// streamInfo converts a live stream into tool output. The stream key lets
+// anyone broadcast to the channel, so it is masked unless revealKey is set.
+func streamInfo(stream *youtube.LiveStream, revealKey bool) map[string]interface{} {
+	info := map[string]interface{}{
+		"stream_id": stream.Id,
+	}
+	if snippet := stream.Snippet; snippet != nil {
+		info["title"] = snippet.Title
+		info["description"] = snippet.Description
+		info["channel_id"] = snippet.ChannelId
+	}
+	if status := stream.Status; status != nil {
+		info["stream_status"] = status.StreamStatus
+		if health := status.HealthStatus; health != nil {
+			var issues []map[string]interface{}
+			for _, issue := range health.ConfigurationIssues {
+				issues = append(issues, map[string]interface{}{
+					"type":        issue.Type,
+					"severity":    issue.Severity,
+					"reason":      issue.Reason,
+					"description": issue.Description,
+				})
+			}
+			info["health_status"] = health.Status
+			info["configuration_issues"] = issues
+		}
+	}
+	if cdn := stream.Cdn; cdn != nil {
+		info["ingestion_type"] = cdn.IngestionType
+		info["resolution"] = cdn.Resolution
+		info["frame_rate"] = cdn.FrameRate
+		if ingestion := cdn.IngestionInfo; ingestion != nil {
+			streamKey := ingestion.StreamName
+			if !revealKey && streamKey != "" {
+				streamKey = maskKey(streamKey)
+			}
+			info["ingestion"] = map[string]interface{}{
+				"ingestion_address":        ingestion.IngestionAddress,
+				"backup_ingestion_address": ingestion.BackupIngestionAddress,
+				"rtmps_ingestion_address":  ingestion.RtmpsIngestionAddress,
+				"stream_key":               streamKey,
+			}
+		}
+	}
+	if stream.ContentDetails != nil {
+		info["is_reusable"] = stream.ContentDetails.IsReusable
+	}
+	return info
+}
+
+// setupLiveTools registers the tools that read live broadcasts and streams
+func setupLiveTools(server *mcp.Server, accounts *AccountManager) {
+	// List live broadcasts tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_live_broadcasts",
+		Description: "List the authenticated user's live broadcasts (requires OAuth2): by broadcast_ids, by status (all, upcoming, active or completed) or all of them. Each broadcast includes scheduled and actual start and end times, lifecycle status and the bound stream; include_streams adds the bound stream's health and ingestion info. Stream keys are masked unless reveal_stream_keys is set. Optional max_results (default 10) and page_token.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListLiveBroadcastsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch args.Status {
+		case "", "all", "upcoming", "active", "completed":
+		default:
+			return nil, nil, fmt.Errorf("invalid status %q (expected all, upcoming, active or completed)", args.Status)
+		}
+		if len(args.BroadcastIDs) > 0 && args.Status != "" {
+			return nil, nil, fmt.Errorf("broadcast_ids and status cannot be combined")
+		}
+		if args.MaxResults == 0 {
+			args.MaxResults = 10
+		}
+
+		response, err := youtubeClient.ListLiveBroadcasts(args.BroadcastIDs, args.Status, args.MaxResults, args.PageToken)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list live broadcasts: %v", err)
+		}
+
+		// Fetch all bound streams in one call
+		streams := map[string]map[string]interface{}{}
+		if args.IncludeStreams {
+			var streamIDs []string
+			for _, broadcast := range response.Items {
+				if broadcast.ContentDetails != nil && broadcast.ContentDetails.BoundStreamId != "" {
+					streamIDs = append(streamIDs, broadcast.ContentDetails.BoundStreamId)
+				}
+			}
+			if len(streamIDs) > 0 {
+				streamResponse, err := youtubeClient.ListLiveStreams(streamIDs, 0, "")
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get bound streams: %v", err)
+				}
+				for _, stream := range streamResponse.Items {
+					streams[stream.Id] = streamInfo(stream, args.RevealStreamKeys)
+				}
+			}
+		}
+
+		var broadcasts []map[string]interface{}
+		for _, broadcast := range response.Items {
+			info := broadcastInfo(broadcast)
+			if broadcast.ContentDetails != nil {
+				if stream, ok := streams[broadcast.ContentDetails.BoundStreamId]; ok {
+					info["bound_stream"] = stream
+				}
+			}
+			broadcasts = append(broadcasts, info)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"broadcasts":      broadcasts,
+			"next_page_token": response.NextPageToken,
+			"total_results":   totalResults(response.PageInfo),
+		})
+	})
+
+	// List live streams tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_live_streams",
+		Description: "List the authenticated user's live streams (requires OAuth2), or those with the given stream_ids, with stream status, health status and configuration issues, and ingestion info. Stream keys are masked unless reveal_stream_keys is set. Optional max_results (default 10) and page_token.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListLiveStreamsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.MaxResults == 0 {
+			args.MaxResults = 10
+		}
+
+		response, err := youtubeClient.ListLiveStreams(args.StreamIDs, args.MaxResults, args.PageToken)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list live streams: %v", err)
+		}
+
+		var streams []map[string]interface{}
+		for _, stream := range response.Items {
+			streams = append(streams, streamInfo(stream, args.RevealStreamKeys))
+		}
+
+		return jsonResult(map[string]interface{}{
+			"streams":         streams,
+			"next_page_token": response.NextPageToken,
+			"total_results":   totalResults(response.PageInfo),
+		})
+	})
+}
+
+// setupLiveWriteTools registers the tools that schedule and control live broadcasts
+func setupLiveWriteTools(server *mcp.Server, accounts *AccountManager) {
+	// Create live broadcast tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_live_broadcast",
+		Description: "Schedule a live broadcast on the authenticated user's channel. Accepts title, scheduled_start_time (RFC 3339), optional description, scheduled_end_time, privacy_status (private, public or unlisted; default private), made_for_kids, enable_auto_start, enable_auto_stop, enable_dvr, enable_monitor_stream (default true), latency_preference (normal, low or ultraLow) and a stream_id to bind right away. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CreateLiveBroadcastArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.Title == "" {
+			return nil, nil, fmt.Errorf("title must not be empty")
+		}
+		start, err := parseOptionalTime("scheduled_start_time", args.ScheduledStartTime)
+		if err != nil {
+			return nil, nil, err
+		}
+		if start.IsZero() {
+			return nil, nil, fmt.Errorf("scheduled_start_time must be given")
+		}
+		if err := validBroadcastTime("scheduled_end_time", &args.ScheduledEndTime); err != nil {
+			return nil, nil, err
+		}
+		if args.PrivacyStatus == "" {
+			args.PrivacyStatus = "private"
+		}
+		if err := validPrivacyStatus(args.PrivacyStatus); err != nil {
+			return nil, nil, err
+		}
+		if err := validLatencyPreference(args.LatencyPreference); err != nil {
+			return nil, nil, err
+		}
+		monitor := true
+		if args.EnableMonitorStream != nil {
+			monitor = *args.EnableMonitorStream
+		}
+
+		broadcast, err := youtubeClient.CreateLiveBroadcast(LiveBroadcastSettings{
+			Title:              args.Title,
+			Description:        args.Description,
+			ScheduledStartTime: start.Format(time.RFC3339),
+			ScheduledEndTime:   args.ScheduledEndTime,
+			PrivacyStatus:      args.PrivacyStatus,
+			MadeForKids:        args.MadeForKids,
+			EnableAutoStart:    args.EnableAutoStart,
+			EnableAutoStop:     args.EnableAutoStop,
+			EnableDvr:          args.EnableDvr,
+			EnableMonitor:      monitor,
+			LatencyPreference:  args.LatencyPreference,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create live broadcast: %v", err)
+		}
+
+		// Report a failed bind without losing the created broadcast
+		result := broadcastInfo(broadcast)
+		if args.StreamID != "" {
+			bound, err := youtubeClient.BindBroadcastStream(broadcast.Id, args.StreamID)
+			if err != nil {
+				result["bind_error"] = err.Error()
+			} else {
+				result = broadcastInfo(bound)
+			}
+		}
+
+		return jsonResult(result)
+	})
+
+	// Bind broadcast stream tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "bind_broadcast_stream",
+		Description: "Bind a live stream (stream_id, see list_live_streams) to a broadcast, or unbind the current stream when stream_id is omitted. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args BindBroadcastStreamArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		broadcast, err := youtubeClient.BindBroadcastStream(args.BroadcastID, args.StreamID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to bind broadcast stream: %v", err)
+		}
+
+		return jsonResult(broadcastInfo(broadcast))
+	})
+
+	// Transition broadcast tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "transition_broadcast",
+		Description: "Move a broadcast through its lifecycle: status testing (from ready, when the monitor stream is enabled), live (from testing, or from ready when the monitor stream is disabled) or complete (from live). Invalid transitions are refused with an explanation. Before testing or live the bound stream must be active with good or ok health, unless ignore_stream_health is set. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args TransitionBroadcastArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		broadcast, err := youtubeClient.GetLiveBroadcast(args.BroadcastID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to transition broadcast: %v", err)
+		}
+		if err := checkBroadcastTransition(broadcast, args.Status); err != nil {
+			return nil, nil, err
+		}
+
+		var stream *youtube.LiveStream
+		if args.Status != "complete" {
+			if broadcast.ContentDetails == nil || broadcast.ContentDetails.BoundStreamId == "" {
+				return nil, nil, fmt.Errorf("cannot transition to %s: no stream is bound to the broadcast (see bind_broadcast_stream)", args.Status)
+			}
+			stream, err = youtubeClient.GetLiveStream(broadcast.ContentDetails.BoundStreamId)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to check bound stream: %v", err)
+			}
+			if err := checkStreamHealth(stream); err != nil && !args.IgnoreStreamHealth {
+				return nil, nil, fmt.Errorf("cannot transition to %s: %v", args.Status, err)
+			}
+		}
+
+		transitioned, err := youtubeClient.TransitionBroadcast(args.BroadcastID, args.Status)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to transition broadcast: %v", err)
+		}
+
+		result := broadcastInfo(transitioned)
+		result["previous_life_cycle_status"] = broadcast.Status.LifeCycleStatus
+		if stream != nil {
+			result["bound_stream"] = streamInfo(stream, false)
+		}
+		return jsonResult(result)
+	})
+
+	// Update broadcast tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_broadcast",
+		Description: "Update a broadcast's title, description, scheduled_start_time, scheduled_end_time, privacy_status, enable_auto_start, enable_auto_stop or enable_dvr. Only the fields supplied are changed. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UpdateBroadcastArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := validBroadcastTime("scheduled_start_time", args.ScheduledStartTime); err != nil {
+			return nil, nil, err
+		}
+		if err := validBroadcastTime("scheduled_end_time", args.ScheduledEndTime); err != nil {
+			return nil, nil, err
+		}
+		if args.PrivacyStatus != nil {
+			if err := validPrivacyStatus(*args.PrivacyStatus); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		broadcast, err := youtubeClient.UpdateBroadcast(args.BroadcastID, BroadcastUpdate{
+			Title:              args.Title,
+			Description:        args.Description,
+			ScheduledStartTime: args.ScheduledStartTime,
+			ScheduledEndTime:   args.ScheduledEndTime,
+			PrivacyStatus:      args.PrivacyStatus,
+			EnableAutoStart:    args.EnableAutoStart,
+			EnableAutoStop:     args.EnableAutoStop,
+			EnableDvr:          args.EnableDvr,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to update broadcast: %v", err)
+		}
+
+		return jsonResult(broadcastInfo(broadcast))
+	})
+}
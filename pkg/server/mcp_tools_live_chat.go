@@ -0,0 +1,261 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// maxLiveChatPolls caps how many pages get_live_chat_messages fetches in one call
+const maxLiveChatPolls = 20
+
+// maxLiveChatMessageChars is the longest text message a live chat accepts
+const maxLiveChatMessageChars = 200
+
+// GetLiveChatMessagesArgs represents arguments for reading a live chat
+type GetLiveChatMessagesArgs struct {
+	VideoID    string `json:"video_id,omitempty"`
+	LiveChatID string `json:"live_chat_id,omitempty"`
+	PageToken  string `json:"page_token,omitempty"`
+	Polls      int    `json:"polls,omitempty"`
+	MaxResults int64  `json:"max_results,omitempty"`
+	Account    string `json:"account,omitempty"`
+}
+
+// PostLiveChatMessageArgs represents arguments for posting to a live chat
+type PostLiveChatMessageArgs struct {
+	VideoID    string `json:"video_id,omitempty"`
+	LiveChatID string `json:"live_chat_id,omitempty"`
+	Text       string `json:"text"`
+	Account    string `json:"account,omitempty"`
+}
+
+// DeleteLiveChatMessageArgs represents arguments for deleting a live chat message
+type DeleteLiveChatMessageArgs struct {
+	MessageID string `json:"message_id"`
+	Account   string `json:"account,omitempty"`
+}
+
+// resolveLiveChatID returns the given live chat ID, or the active live chat of the given video
+func resolveLiveChatID(youtubeClient *YouTubeClient, videoID, liveChatID string) (string, error) {
+	if (videoID == "") == (liveChatID == "") {
+		return "", fmt.Errorf("exactly one of video_id or live_chat_id must be given")
+	}
+	if liveChatID != "" {
+		return liveChatID, nil
+	}
+	return youtubeClient.ActiveLiveChatID(videoID)
+}
+
+// liveChatMessageInfo converts a live chat message into tool output
+func liveChatMessageInfo(message *youtube.LiveChatMessage) map[string]interface{} {
+	info := map[string]interface{}{
+		"message_id": message.Id,
+	}
+	if author := message.AuthorDetails; author != nil {
+		info["author"] = map[string]interface{}{
+			"channel_id":   author.ChannelId,
+			"display_name": author.DisplayName,
+			"is_owner":     author.IsChatOwner,
+			"is_moderator": author.IsChatModerator,
+			"is_member":    author.IsChatSponsor,
+			"is_verified":  author.IsVerified,
+		}
+	}
+
+	snippet := message.Snippet
+	if snippet == nil {
+		return info
+	}
+	info["type"] = snippet.Type
+	info["published_at"] = snippet.PublishedAt
+	info["text"] = snippet.DisplayMessage
+
+	switch {
+	case snippet.SuperChatDetails != nil:
+		details := snippet.SuperChatDetails
+		info["amount"] = details.AmountDisplayString
+		info["amount_micros"] = details.AmountMicros
+		info["currency"] = details.Currency
+		info["tier"] = details.Tier
+		info["comment"] = details.UserComment
+	case snippet.SuperStickerDetails != nil:
+		details := snippet.SuperStickerDetails
+		info["amount"] = details.AmountDisplayString
+		info["amount_micros"] = details.AmountMicros
+		info["currency"] = details.Currency
+		info["tier"] = details.Tier
+		if details.SuperStickerMetadata != nil {
+			info["sticker"] = details.SuperStickerMetadata.AltText
+		}
+	case snippet.NewSponsorDetails != nil:
+		info["level_name"] = snippet.NewSponsorDetails.MemberLevelName
+		info["is_upgrade"] = snippet.NewSponsorDetails.IsUpgrade
+	case snippet.MemberMilestoneChatDetails != nil:
+		info["level_name"] = snippet.MemberMilestoneChatDetails.MemberLevelName
+		info["member_months"] = snippet.MemberMilestoneChatDetails.MemberMonth
+		info["comment"] = snippet.MemberMilestoneChatDetails.UserComment
+	case snippet.MembershipGiftingDetails != nil:
+		info["level_name"] = snippet.MembershipGiftingDetails.GiftMembershipsLevelName
+		info["gift_count"] = snippet.MembershipGiftingDetails.GiftMembershipsCount
+	case snippet.GiftMembershipReceivedDetails != nil:
+		info["level_name"] = snippet.GiftMembershipReceivedDetails.MemberLevelName
+		info["gifter_channel_id"] = snippet.GiftMembershipReceivedDetails.GifterChannelId
+	case snippet.MessageDeletedDetails != nil:
+		info["deleted_message_id"] = snippet.MessageDeletedDetails.DeletedMessageId
+	case snippet.UserBannedDetails != nil:
+		info["ban_type"] = snippet.UserBannedDetails.BanType
+		info["ban_duration_seconds"] = snippet.UserBannedDetails.BanDurationSeconds
+		if user := snippet.UserBannedDetails.BannedUserDetails; user != nil {
+			info["banned_channel_id"] = user.ChannelId
+			info["banned_display_name"] = user.DisplayName
+		}
+	}
+
+	return info
+}
+
+// liveChatCategory groups live chat message types into the buckets get_live_chat_messages returns
+func liveChatCategory(messageType string) string {
+	switch messageType {
+	case "textMessageEvent":
+		return "messages"
+	case "superChatEvent", "superStickerEvent":
+		return "super_chats"
+	case "newSponsorEvent", "memberMilestoneChatEvent", "membershipGiftingEvent", "giftMembershipReceivedEvent":
+		return "membership_events"
+	default:
+		return "other_events"
+	}
+}
+
+// setupLiveChatTools registers the tools that read live chats
+func setupLiveChatTools(server *mcp.Server, accounts *AccountManager) {
+	// Get live chat messages tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_live_chat_messages",
+		Description: "Read the live chat of a live or upcoming video (video_id) or a live chat (live_chat_id). Returns text messages, super chats and stickers, and membership events, plus a next_page_token to continue from later. With polls > 1 (max 20) the chat is polled repeatedly at the interval YouTube asks for; cancel the call to stop early.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetLiveChatMessagesArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.Polls == 0 {
+			args.Polls = 1
+		}
+		if args.Polls < 0 || args.Polls > maxLiveChatPolls {
+			return nil, nil, fmt.Errorf("polls must be between 1 and %d", maxLiveChatPolls)
+		}
+		if args.MaxResults == 0 {
+			args.MaxResults = 500
+		}
+
+		liveChatID, err := resolveLiveChatID(youtubeClient, args.VideoID, args.LiveChatID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get live chat messages: %v", err)
+		}
+
+		result := map[string]interface{}{
+			"live_chat_id": liveChatID,
+		}
+		events := map[string][]map[string]interface{}{
+			"messages":          {},
+			"super_chats":       {},
+			"membership_events": {},
+			"other_events":      {},
+		}
+		notify := progressNotifier(ctx, req, "Polling live chat "+liveChatID)
+
+		polls := 0
+		for response, err := range youtubeClient.LiveChatPages(ctx, liveChatID, args.PageToken, args.MaxResults) {
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get live chat messages: %v", err)
+			}
+
+			for _, message := range response.Items {
+				category := "other_events"
+				if message.Snippet != nil {
+					category = liveChatCategory(message.Snippet.Type)
+				}
+				events[category] = append(events[category], liveChatMessageInfo(message))
+			}
+			result["next_page_token"] = response.NextPageToken
+			result["polling_interval_millis"] = response.PollingIntervalMillis
+			if response.OfflineAt != "" {
+				result["offline_at"] = response.OfflineAt
+			}
+
+			polls++
+			if notify != nil {
+				notify(int64(polls), int64(args.Polls))
+			}
+			if polls == args.Polls {
+				break
+			}
+		}
+
+		for category, messages := range events {
+			result[category] = messages
+		}
+		return jsonResult(result)
+	})
+}
+
+// setupLiveChatWriteTools registers the tools that post to and delete from live chats
+func setupLiveChatWriteTools(server *mcp.Server, accounts *AccountManager) {
+	// Post live chat message tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "post_live_chat_message",
+		Description: "Post a text message (at most 200 characters) to the live chat of a video (video_id) or a live chat (live_chat_id) as the authenticated user. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args PostLiveChatMessageArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.Text == "" {
+			return nil, nil, fmt.Errorf("text must not be empty")
+		}
+		if utf8.RuneCountInString(args.Text) > maxLiveChatMessageChars {
+			return nil, nil, fmt.Errorf("text is longer than %d characters", maxLiveChatMessageChars)
+		}
+
+		liveChatID, err := resolveLiveChatID(youtubeClient, args.VideoID, args.LiveChatID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to post live chat message: %v", err)
+		}
+
+		message, err := youtubeClient.PostLiveChatMessage(liveChatID, args.Text)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to post live chat message: %v", err)
+		}
+
+		info := liveChatMessageInfo(message)
+		info["live_chat_id"] = liveChatID
+		return jsonResult(info)
+	})
+
+	// Delete live chat message tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_live_chat_message",
+		Description: "Delete a live chat message by message_id (from get_live_chat_messages). The authenticated user must own or moderate the chat. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args DeleteLiveChatMessageArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := youtubeClient.DeleteLiveChatMessage(args.MessageID); err != nil {
+			return nil, nil, fmt.Errorf("failed to delete live chat message: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"message_id": args.MessageID,
+			"deleted":    true,
+		})
+	})
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// testBroadcast returns a broadcast in the given lifecycle state, with its
+// monitor stream enabled or disabled, or left unset when monitored is nil
+func testBroadcast(status string, monitored *bool) *youtube.LiveBroadcast {
+	broadcast := &youtube.LiveBroadcast{Id: "b1", Status: &youtube.LiveBroadcastStatus{LifeCycleStatus: status}}
+	if monitored != nil {
+		broadcast.ContentDetails = &youtube.LiveBroadcastContentDetails{
+			MonitorStream: &youtube.MonitorStreamInfo{EnableMonitorStream: monitored},
+		}
+	}
+	return broadcast
+}
+
+func TestCheckBroadcastTransition(t *testing.T) {
+	tests := []struct {
+		name      string
+		broadcast *youtube.LiveBroadcast
+		target    string
+		wantErr   string
+	}{
+		{"ready to testing", testBroadcast("ready", boolPtr(true)), "testing", ""},
+		{"testing to live", testBroadcast("testing", boolPtr(true)), "live", ""},
+		{"ready to live without monitor stream", testBroadcast("ready", boolPtr(false)), "live", ""},
+		{"live to complete", testBroadcast("live", nil), "complete", ""},
+		{"monitor stream defaults to enabled", testBroadcast("ready", nil), "testing", ""},
+		{"unknown target", testBroadcast("ready", nil), "started", `invalid status "started"`},
+		{"already in the target state", testBroadcast("live", nil), "live", "broadcast is already live"},
+		{"created broadcast", testBroadcast("created", nil), "testing", `from "created" to "testing": it must be ready first`},
+		{"complete before live", testBroadcast("testing", nil), "complete", "it must be live first"},
+		{"no status", &youtube.LiveBroadcast{Id: "b1"}, "live", `from "" to "live": it must be ready or testing first`},
+		{"testing without monitor stream", testBroadcast("ready", boolPtr(false)), "testing", "monitor stream is disabled"},
+		{"ready to live with monitor stream", testBroadcast("ready", boolPtr(true)), "live", "must be transitioned to testing first"},
+		{"ready to live with default monitor stream", testBroadcast("ready", nil), "live", "must be transitioned to testing first"},
+		{"complete to live", testBroadcast("complete", nil), "live", `from "complete" to "live"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBroadcastTransition(tt.broadcast, tt.target)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckStreamHealth(t *testing.T) {
+	stream := func(status, health string, issues ...string) *youtube.LiveStream {
+		s := &youtube.LiveStream{Id: "s1", Status: &youtube.LiveStreamStatus{StreamStatus: status}}
+		if health != "" {
+			s.Status.HealthStatus = &youtube.LiveStreamHealthStatus{Status: health}
+			for _, issue := range issues {
+				s.Status.HealthStatus.ConfigurationIssues = append(s.Status.HealthStatus.ConfigurationIssues,
+					&youtube.LiveStreamConfigurationIssue{Description: issue})
+			}
+		}
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		stream  *youtube.LiveStream
+		wantErr string
+	}{
+		{"good", stream("active", "good"), ""},
+		{"ok", stream("active", "ok"), ""},
+		{"no status", &youtube.LiveStream{Id: "s1"}, "stream s1 has no status"},
+		{"not receiving video", stream("ready", "good"), `stream s1 is "ready", not active`},
+		{"unknown health", stream("active", ""), `health is "unknown"`},
+		{"bad health lists issues", stream("active", "bad", "Bitrate too low", "No audio"), `health is "bad": Bitrate too low; No audio (set ignore_stream_health`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStreamHealth(tt.stream)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// ListMembersArgs represents arguments for listing channel members
+type ListMembersArgs struct {
+	Mode             string   `json:"mode,omitempty"`
+	LevelID          string   `json:"level_id,omitempty"`
+	MemberChannelIDs []string `json:"member_channel_ids,omitempty"`
+	MaxResults       int64    `json:"max_results,omitempty"`
+	PageToken        string   `json:"page_token,omitempty"`
+	Account          string   `json:"account,omitempty"`
+}
+
+// ListMembershipLevelsArgs represents arguments for listing membership levels
+type ListMembershipLevelsArgs struct {
+	Account string `json:"account,omitempty"`
+}
+
+// memberInfo converts a channel member into tool output
+func memberInfo(member *youtube.Member) map[string]interface{} {
+	info := map[string]interface{}{}
+	if member.Snippet == nil {
+		return info
+	}
+	if details := member.Snippet.MemberDetails; details != nil {
+		info["channel_id"] = details.ChannelId
+		info["display_name"] = details.DisplayName
+		info["channel_url"] = details.ChannelUrl
+	}
+	if memberships := member.Snippet.MembershipsDetails; memberships != nil {
+		info["level_id"] = memberships.HighestAccessibleLevel
+		info["level_name"] = memberships.HighestAccessibleLevelDisplayName
+		info["accessible_level_ids"] = memberships.AccessibleLevels
+		if duration := memberships.MembershipsDuration; duration != nil {
+			info["member_since"] = duration.MemberSince
+			info["total_months"] = duration.MemberTotalDurationMonths
+		}
+		var levels []map[string]interface{}
+		for _, level := range memberships.MembershipsDurationAtLevels {
+			levels = append(levels, map[string]interface{}{
+				"level_id":     level.Level,
+				"member_since": level.MemberSince,
+				"total_months": level.MemberTotalDurationMonths,
+			})
+		}
+		info["months_per_level"] = levels
+	}
+	return info
+}
+
+// setupMembershipTools registers the channel membership tools
+func setupMembershipTools(server *mcp.Server, accounts *AccountManager) {
+	// List members tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_members",
+		Description: "List the members of the authenticated user's channel with their level and membership duration. mode is all_current (default, newest first) or updates (only members who joined since the previous updates call). Optional level_id (see list_membership_levels) to list members with access to that level, member_channel_ids to check specific channels, max_results (default 10, max 1000) and page_token. Requires OAuth2 with the channel memberships scope.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListMembersArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch args.Mode {
+		case "", "all_current", "updates":
+		default:
+			return nil, nil, fmt.Errorf("invalid mode %q (expected all_current or updates)", args.Mode)
+		}
+		if len(args.MemberChannelIDs) > 100 {
+			return nil, nil, fmt.Errorf("at most 100 member_channel_ids can be checked at once")
+		}
+		if args.MaxResults == 0 {
+			args.MaxResults = 10
+		}
+
+		response, err := youtubeClient.ListMembers(args.Mode, args.LevelID, args.MemberChannelIDs, args.MaxResults, args.PageToken)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list members: %v", err)
+		}
+
+		var members []map[string]interface{}
+		for _, member := range response.Items {
+			members = append(members, memberInfo(member))
+		}
+
+		return jsonResult(map[string]interface{}{
+			"members":         members,
+			"next_page_token": response.NextPageToken,
+			"total_results":   totalResults(response.PageInfo),
+		})
+	})
+
+	// List membership levels tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_membership_levels",
+		Description: "List the membership levels (tiers) offered by the authenticated user's channel, with their IDs and display names. Requires OAuth2 with the channel memberships scope.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListMembershipLevelsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		levels, err := youtubeClient.ListMembershipLevels()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list membership levels: %v", err)
+		}
+
+		var levelList []map[string]interface{}
+		for _, level := range levels {
+			info := map[string]interface{}{
+				"level_id": level.Id,
+			}
+			if level.Snippet != nil && level.Snippet.LevelDetails != nil {
+				info["display_name"] = level.Snippet.LevelDetails.DisplayName
+			}
+			levelList = append(levelList, info)
+		}
+
+		return jsonResult(levelList)
+	})
+}
@@ -13,37 +13,61 @@ type SearchVideosArgs struct {
 	Query      string `json:"query"`
 	MaxResults int64  `json:"max_results,omitempty"`
 	ChannelID  string `json:"channel_id,omitempty"`
+	Account    string `json:"account,omitempty"`
 }
 
 // GetChannelInfoArgs represents arguments for getting channel information
 type GetChannelInfoArgs struct {
-	ChannelID string `json:"channel_id,omitempty"`
+	ChannelID string   `json:"channel_id,omitempty"`
+	Parts     []string `json:"parts,omitempty"`
+	Account   string   `json:"account,omitempty"`
 }
 
 // GetVideoDetailsArgs represents arguments for getting video details
 type GetVideoDetailsArgs struct {
-	VideoID string `json:"video_id"`
+	VideoID string   `json:"video_id"`
+	Parts   []string `json:"parts,omitempty"`
+	Account string   `json:"account,omitempty"`
 }
 
 // GetPlaylistItemsArgs represents arguments for getting playlist items
 type GetPlaylistItemsArgs struct {
 	PlaylistID string `json:"playlist_id"`
 	MaxResults int64  `json:"max_results,omitempty"`
+	Account    string `json:"account,omitempty"`
 }
 
 // SearchChannelsArgs represents arguments for channel search
 type SearchChannelsArgs struct {
 	Query      string `json:"query"`
 	MaxResults int64  `json:"max_results,omitempty"`
+	Account    string `json:"account,omitempty"`
+}
+
+// ListAccountsArgs represents arguments for listing accounts
+type ListAccountsArgs struct{}
+
+// GetAPIKeyStatusArgs represents arguments for getting API key usage
+type GetAPIKeyStatusArgs struct {
+	Account string `json:"account,omitempty"`
 }
 
 // SetupOfficialMCPTools registers all MCP tools with the official SDK server
-func SetupOfficialMCPTools(server *mcp.Server, youtubeClient *YouTubeClient) error {
+func SetupOfficialMCPTools(server *mcp.Server, accounts *AccountManager) error {
+	// Every tool accepts fields and max_description_chars to keep its output small;
+	// the middleware adds them to each tool's input schema
+	server.AddReceivingMiddleware(outputOptionsMiddleware)
+
 	// Search videos tool
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "search_videos",
 		Description: "Search for YouTube videos based on a query. Accepts query string, optional max_results (default 10), and optional channel_id to limit search to specific channel.",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args SearchVideosArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+		
 		if args.MaxResults == 0 {
 			args.MaxResults = 10
 		}
@@ -80,27 +104,23 @@ func SetupOfficialMCPTools(server *mcp.Server, youtubeClient *YouTubeClient) err
 	// Get channel info tool
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_channel_info",
-		Description: "Get information about a YouTube channel",
+		Description: "Get information about a YouTube channel. Optional parts adds brandingSettings, topicDetails (with readable topic names), status, localizations or contentOwnerDetails to the output.",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetChannelInfoArgs) (*mcp.CallToolResult, any, error) {
-		channel, err := youtubeClient.GetChannelInfo(args.ChannelID)
+		youtubeClient, err := accounts.Client(args.Account)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get channel info: %v", err)
+			return nil, nil, err
 		}
 		
-		channelInfo := map[string]interface{}{
-			"channel_id":       channel.Id,
-			"title":            channel.Snippet.Title,
-			"description":      channel.Snippet.Description,
-			"custom_url":       channel.Snippet.CustomUrl,
-			"published_at":     channel.Snippet.PublishedAt,
-			"country":          channel.Snippet.Country,
-			"thumbnail_url":    channel.Snippet.Thumbnails.Medium.Url,
-			"subscriber_count": channel.Statistics.SubscriberCount,
-			"video_count":      channel.Statistics.VideoCount,
-			"view_count":       channel.Statistics.ViewCount,
+		if err := validateParts(args.Parts, channelParts); err != nil {
+			return nil, nil, err
 		}
 		
-		response, err := json.MarshalIndent(channelInfo, "", "  ")
+		channel, err := youtubeClient.GetChannelInfo(args.ChannelID, args.Parts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get channel info: %v", err)
+		}
+		
+		response, err := json.MarshalIndent(channelInfo(channel), "", "  ")
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to marshal response: %v", err)
 		}
@@ -113,34 +133,23 @@ func SetupOfficialMCPTools(server *mcp.Server, youtubeClient *YouTubeClient) err
 	// Get video details tool
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_video_details",
-		Description: "Get detailed information about a YouTube video",
+		Description: "Get detailed information about a YouTube video, including definition, captions, licensing, region restrictions, content ratings and all thumbnails. Optional parts adds status, topicDetails, liveStreamingDetails, localizations, player, recordingDetails or paidProductPlacementDetails to the output.",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetVideoDetailsArgs) (*mcp.CallToolResult, any, error) {
-		video, err := youtubeClient.GetVideoDetails(args.VideoID)
+		youtubeClient, err := accounts.Client(args.Account)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get video details: %v", err)
+			return nil, nil, err
 		}
 		
-		viewCount := video.Statistics.ViewCount
-		likeCount := video.Statistics.LikeCount
-		commentCount := video.Statistics.CommentCount
+		if err := validateParts(args.Parts, videoParts); err != nil {
+			return nil, nil, err
+		}
 		
-		videoInfo := map[string]interface{}{
-			"video_id":       video.Id,
-			"title":          video.Snippet.Title,
-			"description":    video.Snippet.Description,
-			"channel_id":     video.Snippet.ChannelId,
-			"channel_title":  video.Snippet.ChannelTitle,
-			"published_at":   video.Snippet.PublishedAt,
-			"duration":       video.ContentDetails.Duration,
-			"thumbnail_url":  video.Snippet.Thumbnails.Medium.Url,
-			"view_count":     viewCount,
-			"like_count":     likeCount,
-			"comment_count":  commentCount,
-			"tags":           video.Snippet.Tags,
-			"category_id":    video.Snippet.CategoryId,
+		video, err := youtubeClient.GetVideoDetails(args.VideoID, args.Parts, videoAPIFields(outputOptionsFrom(ctx).Fields))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get video details: %v", err)
 		}
 		
-		response, err := json.MarshalIndent(videoInfo, "", "  ")
+		response, err := json.MarshalIndent(videoDetails(youtubeClient, video), "", "  ")
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to marshal response: %v", err)
 		}
@@ -155,6 +164,11 @@ func SetupOfficialMCPTools(server *mcp.Server, youtubeClient *YouTubeClient) err
 		Name:        "get_playlist_items",
 		Description: "Get items from a YouTube playlist",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetPlaylistItemsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+		
 		if args.MaxResults == 0 {
 			args.MaxResults = 10
 		}
@@ -194,6 +208,11 @@ func SetupOfficialMCPTools(server *mcp.Server, youtubeClient *YouTubeClient) err
 		Name:        "search_channels",
 		Description: "Search for YouTube channels based on a query",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, args SearchChannelsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+		
 		if args.MaxResults == 0 {
 			args.MaxResults = 10
 		}
@@ -225,5 +244,101 @@ func SetupOfficialMCPTools(server *mcp.Server, youtubeClient *YouTubeClient) err
 		}, nil, nil
 	})
 
+	// List accounts tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_accounts",
+		Description: "List the configured YouTube accounts with their authentication mode and status. Pass an account name as the account argument of any other tool to use it.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListAccountsArgs) (*mcp.CallToolResult, any, error) {
+		var accountList []map[string]interface{}
+		for _, account := range accounts.Accounts() {
+			status := account.Status()
+			status["default"] = account.Name == accounts.DefaultName()
+			accountList = append(accountList, status)
+		}
+		
+		response, err := json.MarshalIndent(accountList, "", "  ")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal response: %v", err)
+		}
+		
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(response)}},
+		}, nil, nil
+	})
+
+	// Get API key status tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_api_key_status",
+		Description: "Show per-key usage of the API key pool: selection strategy, request count, quota errors and whether each key is exhausted until the daily reset. Covers all accounts unless account is given.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetAPIKeyStatusArgs) (*mcp.CallToolResult, any, error) {
+		names := accounts.Names()
+		if args.Account != "" {
+			names = []string{args.Account}
+		}
+		
+		var keyStatus []map[string]interface{}
+		for _, name := range names {
+			youtubeClient, err := accounts.Client(name)
+			if err != nil {
+				if args.Account != "" {
+					return nil, nil, err
+				}
+				continue
+			}
+			if youtubeClient.keys == nil {
+				continue
+			}
+			status := youtubeClient.keys.snapshot()
+			status["account"] = name
+			keyStatus = append(keyStatus, status)
+		}
+		
+		response, err := json.MarshalIndent(keyStatus, "", "  ")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal response: %v", err)
+		}
+		
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(response)}},
+		}, nil, nil
+	})
+
+	cfg := accounts.Config()
+	setupPlaylistTools(server, accounts)
+	setupSubscriptionTools(server, accounts)
+	setupReferenceTools(server, accounts)
+	setupVideoTools(server, accounts)
+	setupActivityTools(server, accounts)
+	setupChannelSectionTools(server, accounts)
+	setupLiveTools(server, accounts)
+	setupLiveChatTools(server, accounts)
+
+	setupResources(server, accounts)
+
+	// Membership tools need an extra OAuth2 scope, so they are opt-in
+	if cfg.EnableMemberships {
+		setupMembershipTools(server, accounts)
+	}
+
+	if !accounts.ReadOnly() {
+		setupPlaylistWriteTools(server, accounts)
+		setupRatingWriteTools(server, accounts)
+		setupSubscriptionWriteTools(server, accounts)
+		setupPublishingTools(server, accounts)
+		setupCaptionWriteTools(server, accounts)
+		setupLiveWriteTools(server, accounts)
+		setupLiveChatWriteTools(server, accounts)
+		
+		// Comment tools act on other people's comments, so they need their own opt-in
+		if cfg.EnableCommentTools {
+			setupCommentTools(server, accounts)
+		}
+		
+		// Chat moderation acts on real viewers during a live stream, so it is opt-in too
+		if cfg.EnableChatModerationTools {
+			setupChatModerationTools(server, accounts)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,399 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// ListPlaylistsArgs represents arguments for listing playlists
+type ListPlaylistsArgs struct {
+	ChannelID      string `json:"channel_id,omitempty"`
+	Mine           bool   `json:"mine,omitempty"`
+	MaxResults     int64  `json:"max_results,omitempty"`
+	PageToken      string `json:"page_token,omitempty"`
+	IncludeUploads bool   `json:"include_uploads,omitempty"`
+	Account        string `json:"account,omitempty"`
+}
+
+// ListChannelUploadsArgs represents arguments for listing a channel's uploads
+type ListChannelUploadsArgs struct {
+	ChannelID       string `json:"channel_id,omitempty"`
+	PublishedAfter  string `json:"published_after,omitempty"`
+	PublishedBefore string `json:"published_before,omitempty"`
+	Limit           int    `json:"limit,omitempty"`
+	IncludeDetails  bool   `json:"include_details,omitempty"`
+	Account         string `json:"account,omitempty"`
+}
+
+// CreatePlaylistArgs represents arguments for creating a playlist
+type CreatePlaylistArgs struct {
+	Title         string   `json:"title"`
+	Description   string   `json:"description,omitempty"`
+	PrivacyStatus string   `json:"privacy_status,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	Account       string   `json:"account,omitempty"`
+}
+
+// UpdatePlaylistArgs represents arguments for updating a playlist
+type UpdatePlaylistArgs struct {
+	PlaylistID    string  `json:"playlist_id"`
+	Title         *string `json:"title,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	PrivacyStatus *string `json:"privacy_status,omitempty"`
+	Account       string  `json:"account,omitempty"`
+}
+
+// DeletePlaylistArgs represents arguments for deleting a playlist
+type DeletePlaylistArgs struct {
+	PlaylistID string `json:"playlist_id"`
+	Account    string `json:"account,omitempty"`
+}
+
+// AddPlaylistItemArgs represents arguments for adding a video to a playlist
+type AddPlaylistItemArgs struct {
+	PlaylistID string `json:"playlist_id"`
+	VideoID    string `json:"video_id"`
+	Position   *int64 `json:"position,omitempty"`
+	Account    string `json:"account,omitempty"`
+}
+
+// RemovePlaylistItemArgs represents arguments for removing a playlist item
+type RemovePlaylistItemArgs struct {
+	PlaylistItemID string `json:"playlist_item_id"`
+	Account        string `json:"account,omitempty"`
+}
+
+// ReorderPlaylistItemArgs represents arguments for moving a playlist item
+type ReorderPlaylistItemArgs struct {
+	PlaylistItemID string `json:"playlist_item_id"`
+	Position       int64  `json:"position"`
+	Account        string `json:"account,omitempty"`
+}
+
+// validPrivacyStatus checks a privacy status argument
+func validPrivacyStatus(status string) error {
+	switch status {
+	case "private", "public", "unlisted":
+		return nil
+	}
+	return fmt.Errorf("invalid privacy_status %q (expected private, public or unlisted)", status)
+}
+
+// playlistInfo converts a playlist into tool output
+func playlistInfo(playlist *youtube.Playlist) map[string]interface{} {
+	info := map[string]interface{}{
+		"playlist_id": playlist.Id,
+	}
+	if playlist.Snippet != nil {
+		info["title"] = playlist.Snippet.Title
+		info["description"] = playlist.Snippet.Description
+		info["channel_id"] = playlist.Snippet.ChannelId
+		info["channel_title"] = playlist.Snippet.ChannelTitle
+		info["published_at"] = playlist.Snippet.PublishedAt
+		info["thumbnail_url"] = thumbnailURL(playlist.Snippet.Thumbnails)
+		info["tags"] = playlist.Snippet.Tags
+	}
+	if playlist.Status != nil {
+		info["privacy_status"] = playlist.Status.PrivacyStatus
+	}
+	if playlist.ContentDetails != nil {
+		info["item_count"] = playlist.ContentDetails.ItemCount
+	}
+	return info
+}
+
+// playlistItemInfo converts a playlist item into tool output
+func playlistItemInfo(item *youtube.PlaylistItem) map[string]interface{} {
+	info := map[string]interface{}{
+		"playlist_item_id": item.Id,
+	}
+	if item.Snippet != nil {
+		info["playlist_id"] = item.Snippet.PlaylistId
+		info["title"] = item.Snippet.Title
+		info["description"] = item.Snippet.Description
+		info["channel_id"] = item.Snippet.ChannelId
+		info["channel_title"] = item.Snippet.ChannelTitle
+		info["published_at"] = item.Snippet.PublishedAt
+		info["position"] = item.Snippet.Position
+		info["thumbnail_url"] = thumbnailURL(item.Snippet.Thumbnails)
+		if item.Snippet.ResourceId != nil {
+			info["video_id"] = item.Snippet.ResourceId.VideoId
+		}
+	}
+	if item.ContentDetails != nil {
+		info["video_id"] = item.ContentDetails.VideoId
+		info["video_published_at"] = item.ContentDetails.VideoPublishedAt
+	}
+	return info
+}
+
+// setupPlaylistTools registers the tools that read playlists
+func setupPlaylistTools(server *mcp.Server, accounts *AccountManager) {
+	// List playlists tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_playlists",
+		Description: "List the playlists of a channel (channel_id) or of the authenticated user (mine, requires OAuth2). Returns title, description, item count, privacy status and thumbnail for each playlist, plus next_page_token for pagination. Set include_uploads to also resolve the channel's uploads playlist ID, which costs 1 extra quota unit the first time per channel and is cached afterwards.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListPlaylistsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if (args.ChannelID == "") == !args.Mine {
+			return nil, nil, fmt.Errorf("exactly one of channel_id or mine must be given")
+		}
+		if args.MaxResults == 0 {
+			args.MaxResults = 10
+		}
+
+		response, err := youtubeClient.ListPlaylists(args.ChannelID, args.Mine, args.MaxResults, args.PageToken)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list playlists: %v", err)
+		}
+
+		var playlists []map[string]interface{}
+		for _, playlist := range response.Items {
+			playlists = append(playlists, playlistInfo(playlist))
+		}
+
+		result := map[string]interface{}{
+			"playlists":       playlists,
+			"next_page_token": response.NextPageToken,
+			"total_results":   totalResults(response.PageInfo),
+		}
+
+		if args.IncludeUploads {
+			uploadsID, err := youtubeClient.GetUploadsPlaylistID(args.ChannelID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to resolve uploads playlist: %v", err)
+			}
+			result["uploads_playlist_id"] = uploadsID
+		}
+
+		return jsonResult(result)
+	})
+
+	// List channel uploads tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_channel_uploads",
+		Description: "List all uploads of a channel (newest first) by reading its uploads playlist, which costs 1 quota unit per 50 videos instead of 100 per search. Accepts channel_id (empty for the authenticated user's channel), optional published_after/published_before (RFC 3339), limit (0 or -1, the default, for all uploads up to 5000; at most 100 pages of 50 uploads are read, and truncated is set when uploads are left unread) and include_details to add duration and statistics via batched video lookups.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListChannelUploadsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.Limit < -1 {
+			return nil, nil, fmt.Errorf("limit must be positive, or 0 or -1 for all uploads")
+		}
+		if args.Limit > maxChannelUploads {
+			return nil, nil, fmt.Errorf("limit must be at most %d", maxChannelUploads)
+		}
+		publishedAfter, err := parseOptionalTime("published_after", args.PublishedAfter)
+		if err != nil {
+			return nil, nil, err
+		}
+		publishedBefore, err := parseOptionalTime("published_before", args.PublishedBefore)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		uploadsID, items, truncated, err := youtubeClient.ListChannelUploads(args.ChannelID, publishedAfter, publishedBefore, args.Limit)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list channel uploads: %v", err)
+		}
+
+		var videos []map[string]interface{}
+		var videoIDs []string
+		for _, item := range items {
+			info := playlistItemInfo(item)
+			delete(info, "playlist_id")
+			delete(info, "playlist_item_id")
+			videos = append(videos, info)
+			if item.ContentDetails != nil {
+				videoIDs = append(videoIDs, item.ContentDetails.VideoId)
+			}
+		}
+
+		if args.IncludeDetails && len(videoIDs) > 0 {
+			details, err := youtubeClient.GetVideos(videoIDs, []string{"snippet", "statistics", "contentDetails"})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get video details: %v", err)
+			}
+
+			byID := make(map[string]*youtube.Video)
+			for _, video := range details {
+				byID[video.Id] = video
+			}
+			for _, info := range videos {
+				video, ok := byID[fmt.Sprint(info["video_id"])]
+				if !ok {
+					continue
+				}
+				if video.ContentDetails != nil {
+					info["duration"] = video.ContentDetails.Duration
+				}
+				if video.Statistics != nil {
+					info["view_count"] = video.Statistics.ViewCount
+					info["like_count"] = video.Statistics.LikeCount
+					info["comment_count"] = video.Statistics.CommentCount
+				}
+				if video.Snippet != nil {
+					info["tags"] = video.Snippet.Tags
+					info["category_id"] = video.Snippet.CategoryId
+					info["category_name"] = youtubeClient.CategoryName(video.Snippet.CategoryId, "")
+				}
+			}
+		}
+
+		result := map[string]interface{}{
+			"uploads_playlist_id": uploadsID,
+			"count":               len(videos),
+			"videos":              videos,
+		}
+		if truncated {
+			result["truncated"] = true
+			result["note"] = fmt.Sprintf("Stopped after %d uploads or %d pages of the uploads playlist; narrow the date range to see the rest", maxChannelUploads, maxChannelUploadPages)
+		}
+		return jsonResult(result)
+	})
+}
+
+// setupPlaylistWriteTools registers the tools that create and modify playlists
+func setupPlaylistWriteTools(server *mcp.Server, accounts *AccountManager) {
+	// Create playlist tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_playlist",
+		Description: "Create a playlist on the authenticated user's channel. Accepts title, optional description, privacy_status (private, public or unlisted; default private) and tags. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args CreatePlaylistArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.PrivacyStatus == "" {
+			args.PrivacyStatus = "private"
+		}
+		if err := validPrivacyStatus(args.PrivacyStatus); err != nil {
+			return nil, nil, err
+		}
+
+		playlist, err := youtubeClient.CreatePlaylist(args.Title, args.Description, args.PrivacyStatus, args.Tags)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create playlist: %v", err)
+		}
+
+		return jsonResult(playlistInfo(playlist))
+	})
+
+	// Update playlist tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_playlist",
+		Description: "Update a playlist's title, description or privacy_status. Only the fields supplied are changed. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UpdatePlaylistArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.PrivacyStatus != nil {
+			if err := validPrivacyStatus(*args.PrivacyStatus); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		playlist, err := youtubeClient.UpdatePlaylist(args.PlaylistID, PlaylistUpdate{
+			Title:         args.Title,
+			Description:   args.Description,
+			PrivacyStatus: args.PrivacyStatus,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to update playlist: %v", err)
+		}
+
+		return jsonResult(playlistInfo(playlist))
+	})
+
+	// Delete playlist tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_playlist",
+		Description: "Delete a playlist. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args DeletePlaylistArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := youtubeClient.DeletePlaylist(args.PlaylistID); err != nil {
+			return nil, nil, fmt.Errorf("failed to delete playlist: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"playlist_id": args.PlaylistID,
+			"deleted":     true,
+		})
+	})
+
+	// Add playlist item tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_playlist_item",
+		Description: "Add a video to a playlist. Accepts playlist_id, video_id and an optional zero-based position (default: end of the playlist). Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args AddPlaylistItemArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		item, err := youtubeClient.AddPlaylistItem(args.PlaylistID, args.VideoID, args.Position)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to add playlist item: %v", err)
+		}
+
+		return jsonResult(playlistItemInfo(item))
+	})
+
+	// Remove playlist item tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "remove_playlist_item",
+		Description: "Remove an item from a playlist by its playlist_item_id (not the video ID). Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args RemovePlaylistItemArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := youtubeClient.RemovePlaylistItem(args.PlaylistItemID); err != nil {
+			return nil, nil, fmt.Errorf("failed to remove playlist item: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"playlist_item_id": args.PlaylistItemID,
+			"deleted":          true,
+		})
+	})
+
+	// Reorder playlist item tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "reorder_playlist_item",
+		Description: "Move a playlist item to a new zero-based position within its playlist. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ReorderPlaylistItemArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.Position < 0 {
+			return nil, nil, fmt.Errorf("position must not be negative")
+		}
+
+		item, err := youtubeClient.ReorderPlaylistItem(args.PlaylistItemID, args.Position)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to reorder playlist item: %v", err)
+		}
+
+		return jsonResult(playlistItemInfo(item))
+	})
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UploadVideoArgs represents arguments for uploading a video
+type UploadVideoArgs struct {
+	FilePath         string   `json:"file_path"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+	CategoryID       string   `json:"category_id,omitempty"`
+	PrivacyStatus    string   `json:"privacy_status,omitempty"`
+	PublishAt        string   `json:"publish_at,omitempty"`
+	MadeForKids      *bool    `json:"made_for_kids,omitempty"`
+	PlaylistIDs      []string `json:"playlist_ids,omitempty"`
+	ResumeSessionURI string   `json:"resume_session_uri,omitempty"`
+	Account          string   `json:"account,omitempty"`
+}
+
+// UpdateVideoArgs represents arguments for updating video metadata
+type UpdateVideoArgs struct {
+	VideoID             string    `json:"video_id"`
+	Title               *string   `json:"title,omitempty"`
+	Description         *string   `json:"description,omitempty"`
+	Tags                *[]string `json:"tags,omitempty"`
+	CategoryID          *string   `json:"category_id,omitempty"`
+	DefaultLanguage     *string   `json:"default_language,omitempty"`
+	PrivacyStatus       *string   `json:"privacy_status,omitempty"`
+	PublishAt           *string   `json:"publish_at,omitempty"`
+	MadeForKids         *bool     `json:"made_for_kids,omitempty"`
+	Embeddable          *bool     `json:"embeddable,omitempty"`
+	PublicStatsViewable *bool     `json:"public_stats_viewable,omitempty"`
+	License             *string   `json:"license,omitempty"`
+	DryRun              bool      `json:"dry_run,omitempty"`
+	Account             string    `json:"account,omitempty"`
+}
+
+// SetThumbnailArgs represents arguments for setting a custom thumbnail
+type SetThumbnailArgs struct {
+	VideoID  string `json:"video_id"`
+	FilePath string `json:"file_path"`
+	Resize   bool   `json:"resize,omitempty"`
+	Account  string `json:"account,omitempty"`
+}
+
+// setupPublishingTools registers the tools that publish videos
+func setupPublishingTools(server *mcp.Server, accounts *AccountManager) {
+	// Upload video tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "upload_video",
+		Description: "Upload a local video file with the resumable upload protocol. Accepts file_path, title, optional description, tags, category_id, privacy_status (private, public or unlisted; default private), publish_at (RFC 3339, schedules a private video), made_for_kids and playlist_ids to add the video to. Sends progress notifications when the client provides a progress token. If the upload fails part way, the error includes a session URI; call again with the same file_path and resume_session_uri to continue from the bytes already uploaded. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UploadVideoArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.PrivacyStatus == "" {
+			args.PrivacyStatus = "private"
+		}
+		if err := validPrivacyStatus(args.PrivacyStatus); err != nil {
+			return nil, nil, err
+		}
+		if args.PublishAt != "" {
+			if _, err := parseOptionalTime("publish_at", args.PublishAt); err != nil {
+				return nil, nil, err
+			}
+			if args.PrivacyStatus != "private" {
+				return nil, nil, fmt.Errorf("publish_at requires privacy_status private; the video becomes public at that time")
+			}
+		}
+
+		video, err := youtubeClient.UploadVideo(ctx, VideoUpload{
+			FilePath:      args.FilePath,
+			Title:         args.Title,
+			Description:   args.Description,
+			Tags:          args.Tags,
+			CategoryID:    args.CategoryID,
+			PrivacyStatus: args.PrivacyStatus,
+			PublishAt:     args.PublishAt,
+			MadeForKids:   args.MadeForKids,
+			SessionURI:    args.ResumeSessionURI,
+		}, progressNotifier(ctx, req, "Uploading "+args.FilePath))
+		var interrupted *UploadInterruptedError
+		if errors.As(err, &interrupted) {
+			return nil, nil, fmt.Errorf("failed to upload video after %d of %d bytes: %v; call upload_video again with the same file_path and resume_session_uri %q to continue",
+				interrupted.Uploaded, interrupted.Total, interrupted.Err, interrupted.SessionURI)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to upload video: %v", err)
+		}
+
+		result := map[string]interface{}{
+			"video_id": video.Id,
+			"url":      "https://www.youtube.com/watch?v=" + video.Id,
+		}
+		if video.Snippet != nil {
+			result["title"] = video.Snippet.Title
+		}
+		if video.Status != nil {
+			result["upload_status"] = video.Status.UploadStatus
+			result["privacy_status"] = video.Status.PrivacyStatus
+			result["publish_at"] = video.Status.PublishAt
+		}
+
+		// The video is already uploaded, so report playlist failures instead of failing the call
+		var addedTo, playlistErrors []string
+		for _, playlistID := range args.PlaylistIDs {
+			if _, err := youtubeClient.AddPlaylistItem(playlistID, video.Id, nil); err != nil {
+				playlistErrors = append(playlistErrors, fmt.Sprintf("%s: %v", playlistID, err))
+				continue
+			}
+			addedTo = append(addedTo, playlistID)
+		}
+		if len(addedTo) > 0 {
+			result["added_to_playlists"] = addedTo
+		}
+		if len(playlistErrors) > 0 {
+			result["playlist_errors"] = playlistErrors
+		}
+
+		return jsonResult(result)
+	})
+
+	// Update video tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_video",
+		Description: "Update a video's metadata. Only the fields supplied are changed: title, description, tags, category_id, default_language, privacy_status, publish_at (an empty string cancels a scheduled publish), made_for_kids, embeddable, public_stats_viewable and license (youtube or creativeCommon). Returns a field-level diff of before and after values; with dry_run nothing is written. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UpdateVideoArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.PrivacyStatus != nil {
+			if err := validPrivacyStatus(*args.PrivacyStatus); err != nil {
+				return nil, nil, err
+			}
+		}
+		if args.PublishAt != nil {
+			if _, err := parseOptionalTime("publish_at", *args.PublishAt); err != nil {
+				return nil, nil, err
+			}
+		}
+		if args.License != nil && *args.License != "youtube" && *args.License != "creativeCommon" {
+			return nil, nil, fmt.Errorf("invalid license %q (expected youtube or creativeCommon)", *args.License)
+		}
+
+		_, changes, err := youtubeClient.UpdateVideo(args.VideoID, VideoUpdate{
+			Title:               args.Title,
+			Description:         args.Description,
+			Tags:                args.Tags,
+			CategoryID:          args.CategoryID,
+			DefaultLanguage:     args.DefaultLanguage,
+			PrivacyStatus:       args.PrivacyStatus,
+			PublishAt:           args.PublishAt,
+			MadeForKids:         args.MadeForKids,
+			Embeddable:          args.Embeddable,
+			PublicStatsViewable: args.PublicStatsViewable,
+			License:             args.License,
+		}, args.DryRun)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to update video: %v", err)
+		}
+
+		if changes == nil {
+			changes = []FieldChange{}
+		}
+
+		return jsonResult(map[string]interface{}{
+			"video_id": args.VideoID,
+			"dry_run":  args.DryRun,
+			"updated":  !args.DryRun && len(changes) > 0,
+			"changes":  changes,
+		})
+	})
+
+	// Set thumbnail tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "set_thumbnail",
+		Description: "Upload a local JPEG or PNG as a video's custom thumbnail. The image is checked locally first: at most 2MB and at least 640 pixels wide (1280x720 recommended). With resize, an image over 2MB is scaled to fit 1280x720 and saved as a temporary JPEG copy, which is uploaded instead; the original is left untouched. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args SetThumbnailArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		thumb, err := prepareThumbnail(args.FilePath, args.Resize)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		response, err := youtubeClient.SetThumbnail(args.VideoID, thumb.data, thumb.contentType)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set thumbnail: %v", err)
+		}
+
+		result := map[string]interface{}{
+			"video_id": args.VideoID,
+			"width":    thumb.width,
+			"height":   thumb.height,
+			"bytes":    len(thumb.data),
+		}
+		if thumb.resizedPath != "" {
+			result["resized_path"] = thumb.resizedPath
+		}
+		if len(response.Items) > 0 {
+			result["thumbnail_url"] = thumbnailURL(response.Items[0])
+		}
+
+		return jsonResult(result)
+	})
+}
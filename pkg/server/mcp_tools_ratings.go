@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RateVideoArgs represents arguments for rating a video
+type RateVideoArgs struct {
+	VideoID string `json:"video_id"`
+	Rating  string `json:"rating"`
+	Account string `json:"account,omitempty"`
+}
+
+// GetMyRatingsArgs represents arguments for looking up the user's ratings
+type GetMyRatingsArgs struct {
+	VideoIDs []string `json:"video_ids"`
+	Account  string   `json:"account,omitempty"`
+}
+
+// setupRatingWriteTools registers the tools that rate videos and read the
+// user's ratings. videos.getRating needs the same write scope as videos.rate,
+// so both are only available outside read-only mode.
+func setupRatingWriteTools(server *mcp.Server, accounts *AccountManager) {
+	// Get my ratings tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_my_ratings",
+		Description: "Get the authenticated user's rating (like, dislike or none) for each of a batch of video IDs. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetMyRatingsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(args.VideoIDs) == 0 {
+			return nil, nil, fmt.Errorf("video_ids must not be empty")
+		}
+
+		ratings, err := youtubeClient.GetMyRatings(args.VideoIDs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get ratings: %v", err)
+		}
+
+		var ratingList []map[string]interface{}
+		for _, rating := range ratings {
+			ratingList = append(ratingList, map[string]interface{}{
+				"video_id": rating.VideoId,
+				"rating":   rating.Rating,
+			})
+		}
+
+		return jsonResult(ratingList)
+	})
+
+	// Rate video tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "rate_video",
+		Description: "Rate a video as the authenticated user: like, dislike, or none to remove an existing rating. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args RateVideoArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch args.Rating {
+		case "like", "dislike", "none":
+		default:
+			return nil, nil, fmt.Errorf("invalid rating %q (expected like, dislike or none)", args.Rating)
+		}
+
+		if err := youtubeClient.RateVideo(args.VideoID, args.Rating); err != nil {
+			return nil, nil, fmt.Errorf("failed to rate video: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"video_id": args.VideoID,
+			"rating":   args.Rating,
+		})
+	})
+}
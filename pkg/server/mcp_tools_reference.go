@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListVideoCategoriesArgs represents arguments for listing video categories
+type ListVideoCategoriesArgs struct {
+	RegionCode string `json:"region_code,omitempty"`
+	Hl         string `json:"hl,omitempty"`
+	Account    string `json:"account,omitempty"`
+}
+
+// ListRegionsArgs represents arguments for listing content regions
+type ListRegionsArgs struct {
+	Hl      string `json:"hl,omitempty"`
+	Account string `json:"account,omitempty"`
+}
+
+// ListLanguagesArgs represents arguments for listing application languages
+type ListLanguagesArgs struct {
+	Hl      string `json:"hl,omitempty"`
+	Account string `json:"account,omitempty"`
+}
+
+// setupReferenceTools registers the tools that list reference data
+func setupReferenceTools(server *mcp.Server, accounts *AccountManager) {
+	// List video categories tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_video_categories",
+		Description: "List the video categories available in a region (region_code, ISO 3166-1 alpha-2, default US), with names in the language hl. Maps the category_id of videos to names and tells which categories can be assigned to uploads. Cached for a day.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListVideoCategoriesArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.RegionCode == "" {
+			args.RegionCode = defaultCategoryRegion
+		}
+
+		categories, err := youtubeClient.ListVideoCategories(strings.ToUpper(args.RegionCode), args.Hl)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list video categories: %v", err)
+		}
+
+		var categoryList []map[string]interface{}
+		for _, category := range categories {
+			if category.Snippet == nil {
+				continue
+			}
+			categoryList = append(categoryList, map[string]interface{}{
+				"category_id": category.Id,
+				"title":       category.Snippet.Title,
+				"assignable":  category.Snippet.Assignable,
+			})
+		}
+
+		return jsonResult(categoryList)
+	})
+
+	// List regions tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_regions",
+		Description: "List the content regions YouTube supports, as region codes usable for region_code arguments, with names in the language hl. Cached for a day.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListRegionsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		regions, err := youtubeClient.ListRegions(args.Hl)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list regions: %v", err)
+		}
+
+		var regionList []map[string]interface{}
+		for _, region := range regions {
+			if region.Snippet == nil {
+				continue
+			}
+			regionList = append(regionList, map[string]interface{}{
+				"region_code": region.Snippet.Gl,
+				"name":        region.Snippet.Name,
+			})
+		}
+
+		return jsonResult(regionList)
+	})
+
+	// List languages tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_languages",
+		Description: "List the application languages YouTube supports, as language codes usable for hl arguments, with names in the language hl. Cached for a day.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListLanguagesArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		languages, err := youtubeClient.ListLanguages(args.Hl)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list languages: %v", err)
+		}
+
+		var languageList []map[string]interface{}
+		for _, language := range languages {
+			if language.Snippet == nil {
+				continue
+			}
+			languageList = append(languageList, map[string]interface{}{
+				"language_code": language.Snippet.Hl,
+				"name":          language.Snippet.Name,
+			})
+		}
+
+		return jsonResult(languageList)
+	})
+}
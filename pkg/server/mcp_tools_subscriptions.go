@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// ListSubscriptionsArgs represents arguments for listing subscriptions
+type ListSubscriptionsArgs struct {
+	ChannelID  string `json:"channel_id,omitempty"`
+	Mine       bool   `json:"mine,omitempty"`
+	Order      string `json:"order,omitempty"`
+	MaxResults int64  `json:"max_results,omitempty"`
+	PageToken  string `json:"page_token,omitempty"`
+	Account    string `json:"account,omitempty"`
+}
+
+// SubscribeArgs represents arguments for subscribing to a channel
+type SubscribeArgs struct {
+	ChannelID string `json:"channel_id"`
+	Account   string `json:"account,omitempty"`
+}
+
+// UnsubscribeArgs represents arguments for removing a subscription
+type UnsubscribeArgs struct {
+	SubscriptionID string `json:"subscription_id,omitempty"`
+	ChannelID      string `json:"channel_id,omitempty"`
+	Account        string `json:"account,omitempty"`
+}
+
+// subscriptionInfo converts a subscription into tool output
+func subscriptionInfo(subscription *youtube.Subscription) map[string]interface{} {
+	info := map[string]interface{}{
+		"subscription_id": subscription.Id,
+	}
+	if subscription.Snippet != nil {
+		if subscription.Snippet.ResourceId != nil {
+			info["channel_id"] = subscription.Snippet.ResourceId.ChannelId
+		}
+		info["title"] = subscription.Snippet.Title
+		info["description"] = subscription.Snippet.Description
+		info["subscribed_at"] = subscription.Snippet.PublishedAt
+		info["thumbnail_url"] = thumbnailURL(subscription.Snippet.Thumbnails)
+	}
+	if subscription.ContentDetails != nil {
+		info["total_item_count"] = subscription.ContentDetails.TotalItemCount
+		info["new_item_count"] = subscription.ContentDetails.NewItemCount
+	}
+	return info
+}
+
+// setupSubscriptionTools registers the tools that read subscriptions
+func setupSubscriptionTools(server *mcp.Server, accounts *AccountManager) {
+	// List subscriptions tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_subscriptions",
+		Description: "List the channels the authenticated user (mine, requires OAuth2) or a channel with public subscriptions (channel_id) is subscribed to. Optional order (alphabetical, relevance, or unread for mine only), max_results (default 10) and page_token. Each subscription includes the channel ID and, for mine, the count of new items.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args ListSubscriptionsArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if (args.ChannelID == "") == !args.Mine {
+			return nil, nil, fmt.Errorf("exactly one of channel_id or mine must be given")
+		}
+		switch args.Order {
+		case "", "alphabetical", "relevance", "unread":
+		default:
+			return nil, nil, fmt.Errorf("invalid order %q (expected alphabetical, relevance or unread)", args.Order)
+		}
+		if args.Order == "unread" && !args.Mine {
+			return nil, nil, fmt.Errorf("order unread is only supported with mine")
+		}
+		if args.MaxResults == 0 {
+			args.MaxResults = 10
+		}
+
+		response, err := youtubeClient.ListSubscriptions(args.ChannelID, args.Mine, args.Order, args.MaxResults, args.PageToken)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list subscriptions: %v", err)
+		}
+
+		var subscriptions []map[string]interface{}
+		for _, subscription := range response.Items {
+			subscriptions = append(subscriptions, subscriptionInfo(subscription))
+		}
+
+		return jsonResult(map[string]interface{}{
+			"subscriptions":   subscriptions,
+			"next_page_token": response.NextPageToken,
+			"total_results":   totalResults(response.PageInfo),
+		})
+	})
+}
+
+// setupSubscriptionWriteTools registers the tools that change subscriptions
+func setupSubscriptionWriteTools(server *mcp.Server, accounts *AccountManager) {
+	// Subscribe tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "subscribe",
+		Description: "Subscribe the authenticated user to a channel. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args SubscribeArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		subscription, err := youtubeClient.Subscribe(args.ChannelID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to subscribe: %v", err)
+		}
+
+		return jsonResult(subscriptionInfo(subscription))
+	})
+
+	// Unsubscribe tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "unsubscribe",
+		Description: "Remove one of the authenticated user's subscriptions, identified by subscription_id or by the subscribed channel_id. Requires OAuth2 with write access.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args UnsubscribeArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if (args.SubscriptionID == "") == (args.ChannelID == "") {
+			return nil, nil, fmt.Errorf("exactly one of subscription_id or channel_id must be given")
+		}
+		if args.SubscriptionID == "" {
+			args.SubscriptionID, err = youtubeClient.FindSubscriptionID(args.ChannelID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to unsubscribe: %v", err)
+			}
+		}
+
+		if err := youtubeClient.Unsubscribe(args.SubscriptionID); err != nil {
+			return nil, nil, fmt.Errorf("failed to unsubscribe: %v", err)
+		}
+
+		return jsonResult(map[string]interface{}{
+			"subscription_id": args.SubscriptionID,
+			"deleted":         true,
+		})
+	})
+}
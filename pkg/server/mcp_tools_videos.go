@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/youtube/v3"
+)
+
+// GetTrendingVideosArgs represents arguments for getting the most popular chart
+type GetTrendingVideosArgs struct {
+	RegionCode      string `json:"region_code,omitempty"`
+	VideoCategoryID string `json:"video_category_id,omitempty"`
+	MaxResults      int64  `json:"max_results,omitempty"`
+	PageToken       string `json:"page_token,omitempty"`
+	Account         string `json:"account,omitempty"`
+}
+
+// videoInfo converts a video with snippet, statistics and content details into
+// tool output, naming its category as listed in regionCode (default region if empty)
+func videoInfo(youtubeClient *YouTubeClient, video *youtube.Video, regionCode string) map[string]interface{} {
+	info := map[string]interface{}{
+		"video_id": video.Id,
+	}
+	if video.Snippet != nil {
+		info["title"] = video.Snippet.Title
+		info["description"] = video.Snippet.Description
+		info["channel_id"] = video.Snippet.ChannelId
+		info["channel_title"] = video.Snippet.ChannelTitle
+		info["published_at"] = video.Snippet.PublishedAt
+		info["thumbnail_url"] = thumbnailURL(video.Snippet.Thumbnails)
+		info["tags"] = video.Snippet.Tags
+		info["category_id"] = video.Snippet.CategoryId
+		info["category_name"] = youtubeClient.CategoryName(video.Snippet.CategoryId, regionCode)
+	}
+	if video.ContentDetails != nil {
+		info["duration"] = video.ContentDetails.Duration
+	}
+	if video.Statistics != nil {
+		info["view_count"] = video.Statistics.ViewCount
+		info["like_count"] = video.Statistics.LikeCount
+		info["comment_count"] = video.Statistics.CommentCount
+		info["favorite_count"] = video.Statistics.FavoriteCount
+	}
+	return info
+}
+
+// videoParts are the optional video resource parts get_video_details can request
+var videoParts = []string{"status", "topicDetails", "liveStreamingDetails", "localizations", "player", "recordingDetails", "paidProductPlacementDetails"}
+
+// videoDetails extends videoInfo with the full content details and whichever optional parts were fetched
+func videoDetails(youtubeClient *YouTubeClient, video *youtube.Video) map[string]interface{} {
+	info := videoInfo(youtubeClient, video, "")
+
+	if snippet := video.Snippet; snippet != nil {
+		info["thumbnails"] = thumbnailSizes(snippet.Thumbnails)
+		info["live_broadcast_content"] = snippet.LiveBroadcastContent
+		if snippet.DefaultLanguage != "" {
+			info["default_language"] = snippet.DefaultLanguage
+		}
+		if snippet.DefaultAudioLanguage != "" {
+			info["default_audio_language"] = snippet.DefaultAudioLanguage
+		}
+		if localized := snippet.Localized; localized != nil && localized.Title != snippet.Title {
+			info["localized_title"] = localized.Title
+			info["localized_description"] = localized.Description
+		}
+	}
+
+	if details := video.ContentDetails; details != nil {
+		info["definition"] = details.Definition
+		info["dimension"] = details.Dimension
+		info["projection"] = details.Projection
+		info["caption"] = details.Caption == "true"
+		info["licensed_content"] = details.LicensedContent
+		if restriction := details.RegionRestriction; restriction != nil {
+			info["region_restriction"] = map[string]interface{}{
+				"blocked_in": restriction.Blocked,
+				"allowed_in": restriction.Allowed,
+			}
+		}
+		if ratings := contentRatings(details.ContentRating); len(ratings) > 0 {
+			info["content_rating"] = ratings
+		}
+	}
+
+	if status := video.Status; status != nil {
+		statusInfo := map[string]interface{}{
+			"privacy_status":           status.PrivacyStatus,
+			"upload_status":            status.UploadStatus,
+			"license":                  status.License,
+			"embeddable":               status.Embeddable,
+			"public_stats_viewable":    status.PublicStatsViewable,
+			"made_for_kids":            status.MadeForKids,
+			"contains_synthetic_media": status.ContainsSyntheticMedia,
+		}
+		if status.PublishAt != "" {
+			statusInfo["publish_at"] = status.PublishAt
+		}
+		if status.FailureReason != "" {
+			statusInfo["failure_reason"] = status.FailureReason
+		}
+		if status.RejectionReason != "" {
+			statusInfo["rejection_reason"] = status.RejectionReason
+		}
+		info["status"] = statusInfo
+	}
+
+	if topics := video.TopicDetails; topics != nil {
+		info["topics"] = topicNames(topics.TopicCategories)
+		info["topic_urls"] = topics.TopicCategories
+		info["topic_ids"] = topics.TopicIds
+		info["relevant_topic_ids"] = topics.RelevantTopicIds
+	}
+
+	if live := video.LiveStreamingDetails; live != nil {
+		info["live_streaming"] = map[string]interface{}{
+			"scheduled_start_time": live.ScheduledStartTime,
+			"scheduled_end_time":   live.ScheduledEndTime,
+			"actual_start_time":    live.ActualStartTime,
+			"actual_end_time":      live.ActualEndTime,
+			"concurrent_viewers":   live.ConcurrentViewers,
+			"active_live_chat_id":  live.ActiveLiveChatId,
+		}
+	}
+
+	if video.Localizations != nil {
+		localizations := map[string]interface{}{}
+		for language, localization := range video.Localizations {
+			localizations[language] = map[string]interface{}{
+				"title":       localization.Title,
+				"description": localization.Description,
+			}
+		}
+		info["localizations"] = localizations
+	}
+
+	if player := video.Player; player != nil {
+		info["embed_html"] = player.EmbedHtml
+	}
+
+	if recording := video.RecordingDetails; recording != nil {
+		recordingInfo := map[string]interface{}{
+			"recording_date":       recording.RecordingDate,
+			"location_description": recording.LocationDescription,
+		}
+		if location := recording.Location; location != nil {
+			recordingInfo["latitude"] = location.Latitude
+			recordingInfo["longitude"] = location.Longitude
+			recordingInfo["altitude"] = location.Altitude
+		}
+		info["recording"] = recordingInfo
+	}
+
+	if placement := video.PaidProductPlacementDetails; placement != nil {
+		info["has_paid_product_placement"] = placement.HasPaidProductPlacement
+	}
+
+	return info
+}
+
+// thumbnailSizes lists every available thumbnail by size name
+func thumbnailSizes(thumbnails *youtube.ThumbnailDetails) map[string]interface{} {
+	sizes := map[string]interface{}{}
+	if thumbnails == nil {
+		return sizes
+	}
+	for name, thumbnail := range map[string]*youtube.Thumbnail{
+		"default":  thumbnails.Default,
+		"medium":   thumbnails.Medium,
+		"high":     thumbnails.High,
+		"standard": thumbnails.Standard,
+		"maxres":   thumbnails.Maxres,
+	} {
+		if thumbnail != nil {
+			sizes[name] = map[string]interface{}{
+				"url":    thumbnail.Url,
+				"width":  thumbnail.Width,
+				"height": thumbnail.Height,
+			}
+		}
+	}
+	return sizes
+}
+
+// contentRatings returns the ratings a video has been given, keyed by rating system
+func contentRatings(rating *youtube.ContentRating) map[string]interface{} {
+	ratings := map[string]interface{}{}
+	if rating == nil {
+		return ratings
+	}
+	// ContentRating has a field per rating system; the JSON form keeps only those that are set
+	data, err := json.Marshal(rating)
+	if err != nil {
+		return ratings
+	}
+	if err := json.Unmarshal(data, &ratings); err != nil {
+		return map[string]interface{}{}
+	}
+	return ratings
+}
+
+// setupVideoTools registers the video chart tools
+func setupVideoTools(server *mcp.Server, accounts *AccountManager) {
+	// Get trending videos tool
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_trending_videos",
+		Description: "Get the most popular (trending) videos chart for a region with full statistics, at 1 quota unit per page. Accepts region_code (ISO 3166-1 alpha-2, default US), optional video_category_id (see list_video_categories), max_results (default 10, max 50) and page_token.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, args GetTrendingVideosArgs) (*mcp.CallToolResult, any, error) {
+		youtubeClient, err := accounts.Client(args.Account)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if args.RegionCode == "" {
+			args.RegionCode = defaultCategoryRegion
+		}
+		if args.MaxResults == 0 {
+			args.MaxResults = 10
+		}
+
+		response, err := youtubeClient.GetTrendingVideos(strings.ToUpper(args.RegionCode), args.VideoCategoryID, args.MaxResults, args.PageToken,
+			videoAPIFields(outputOptionsFrom(ctx).Fields, "nextPageToken", "pageInfo"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get trending videos: %v", err)
+		}
+
+		var videos []map[string]interface{}
+		for _, video := range response.Items {
+			videos = append(videos, videoInfo(youtubeClient, video, args.RegionCode))
+		}
+
+		return jsonResult(map[string]interface{}{
+			"region_code":     strings.ToUpper(args.RegionCode),
+			"videos":          videos,
+			"next_page_token": response.NextPageToken,
+			"total_results":   totalResults(response.PageInfo),
+		})
+	})
+}
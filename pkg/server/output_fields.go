@@ -0,0 +1,317 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/googleapi"
+)
+
+// outputOptions are the output shaping arguments accepted by every tool. They
+// are added to each tool's input schema and handled by outputOptionsMiddleware,
+// so the tools' own argument structs do not declare them.
+type outputOptions struct {
+	Fields              []string `json:"fields"`
+	MaxDescriptionChars int      `json:"max_description_chars"`
+}
+
+// outputOptionSchemas are the input schema properties of the output options
+var outputOptionSchemas = map[string]*jsonschema.Schema{
+	"fields": {
+		Type:        "array",
+		Items:       &jsonschema.Schema{Type: "string"},
+		Description: "Keep only these keys in each result item, e.g. [\"video_id\", \"title\"]. Envelope keys such as next_page_token are kept. get_video_details and get_trending_videos also request only these fields from the API.",
+	},
+	"max_description_chars": {
+		Type:        "integer",
+		Description: "Truncate descriptions to this many characters",
+	},
+}
+
+// resultItemKeys names the keys holding the result items of tools that wrap
+// them in an envelope. All other tools return their items directly, as a
+// single object or a list of objects.
+var resultItemKeys = map[string][]string{
+	"get_channel_activities": {"activities"},
+	"get_live_chat_messages": {"messages", "super_chats", "membership_events", "other_events"},
+	"get_trending_videos":    {"videos"},
+	"list_channel_uploads":   {"videos"},
+	"list_chat_moderators":   {"moderators"},
+	"list_live_broadcasts":   {"broadcasts"},
+	"list_live_streams":      {"streams"},
+	"list_members":           {"members"},
+	"list_playlists":         {"playlists"},
+	"list_subscriptions":     {"subscriptions"},
+}
+
+type outputOptionsKey struct{}
+
+// outputOptionsFrom returns the output options of the tool call being handled
+func outputOptionsFrom(ctx context.Context) outputOptions {
+	opts, _ := ctx.Value(outputOptionsKey{}).(outputOptions)
+	return opts
+}
+
+// outputOptionsMiddleware adds the fields and max_description_chars arguments
+// to every tool and applies them to the JSON output of tool calls. The
+// arguments are removed before the tool's handler sees them; handlers that
+// can use them earlier read them with outputOptionsFrom.
+func outputOptionsMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		switch method {
+		case "tools/list":
+			result, err := next(ctx, method, req)
+			if listResult, ok := result.(*mcp.ListToolsResult); ok && err == nil {
+				for i, tool := range listResult.Tools {
+					listResult.Tools[i] = withOutputOptions(tool)
+				}
+			}
+			return result, err
+		case "tools/call":
+		default:
+			return next(ctx, method, req)
+		}
+
+		callReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		opts, err := takeOutputOptions(callReq.Params)
+		if err != nil {
+			return nil, err
+		}
+		ctx = context.WithValue(ctx, outputOptionsKey{}, opts)
+
+		result, err := next(ctx, method, req)
+		if err != nil || (len(opts.Fields) == 0 && opts.MaxDescriptionChars <= 0) {
+			return result, err
+		}
+		callResult, ok := result.(*mcp.CallToolResult)
+		if !ok || callResult.IsError {
+			return result, err
+		}
+
+		for _, content := range callResult.Content {
+			text, ok := content.(*mcp.TextContent)
+			if !ok {
+				continue
+			}
+			var value interface{}
+			if json.Unmarshal([]byte(text.Text), &value) != nil {
+				continue
+			}
+			value = shapeOutput(value, resultItemKeys[callReq.Params.Name], opts)
+			shaped, err := json.MarshalIndent(value, "", "  ")
+			if err != nil {
+				log.Printf("Failed to marshal shaped output: %v", err)
+				continue
+			}
+			text.Text = string(shaped)
+		}
+
+		return callResult, nil
+	}
+}
+
+// withOutputOptions returns a copy of the tool with the output options added
+// to its input schema
+func withOutputOptions(tool *mcp.Tool) *mcp.Tool {
+	if tool.InputSchema == nil || tool.InputSchema.Type != "object" {
+		return tool
+	}
+
+	schema := *tool.InputSchema
+	schema.Properties = maps.Clone(schema.Properties)
+	if schema.Properties == nil {
+		schema.Properties = make(map[string]*jsonschema.Schema)
+	}
+	for name, property := range outputOptionSchemas {
+		schema.Properties[name] = property
+	}
+
+	shaped := *tool
+	shaped.InputSchema = &schema
+	return &shaped
+}
+
+// takeOutputOptions parses the output options out of the call arguments and
+// removes them, leaving the arguments the tool itself declares
+func takeOutputOptions(params *mcp.CallToolParams) (outputOptions, error) {
+	var opts outputOptions
+	raw, ok := params.Arguments.(json.RawMessage)
+	if !ok || len(raw) == 0 {
+		return opts, nil
+	}
+
+	var args map[string]json.RawMessage
+	if json.Unmarshal(raw, &args) != nil {
+		// Not an object; leave it for the tool's own validation to reject
+		return opts, nil
+	}
+	if fields, ok := args["fields"]; ok {
+		if err := json.Unmarshal(fields, &opts.Fields); err != nil {
+			return opts, fmt.Errorf("invalid fields argument: %v", err)
+		}
+		delete(args, "fields")
+	}
+	if maxChars, ok := args["max_description_chars"]; ok {
+		if err := json.Unmarshal(maxChars, &opts.MaxDescriptionChars); err != nil {
+			return opts, fmt.Errorf("invalid max_description_chars argument: %v", err)
+		}
+		delete(args, "max_description_chars")
+	}
+
+	stripped, err := json.Marshal(args)
+	if err != nil {
+		return opts, err
+	}
+	params.Arguments = json.RawMessage(stripped)
+	return opts, nil
+}
+
+// shapeOutput projects the result items of a tool's output onto the requested
+// fields and truncates descriptions. itemKeys names the envelope keys holding
+// the items (see resultItemKeys); without them the output itself is the item
+// or list of items.
+func shapeOutput(value interface{}, itemKeys []string, opts outputOptions) interface{} {
+	if len(opts.Fields) > 0 {
+		if len(itemKeys) == 0 {
+			value = projectItems(value, opts.Fields)
+		} else if envelope, ok := value.(map[string]interface{}); ok {
+			for _, key := range itemKeys {
+				if items, ok := envelope[key]; ok {
+					envelope[key] = projectItems(items, opts.Fields)
+				}
+			}
+		}
+	}
+	if opts.MaxDescriptionChars > 0 {
+		truncateDescriptions(value, opts.MaxDescriptionChars)
+	}
+	return value
+}
+
+// projectItems keeps only the given keys of an item or of each item in a list.
+// An item holding none of the keys becomes an empty object.
+func projectItems(value interface{}, fields []string) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			v[i] = projectItems(item, fields)
+		}
+	case map[string]interface{}:
+		for key := range v {
+			if !slices.Contains(fields, key) {
+				delete(v, key)
+			}
+		}
+	}
+	return value
+}
+
+// truncateDescriptions shortens every description in the value in place
+func truncateDescriptions(value interface{}, max int) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, element := range v {
+			truncateDescriptions(element, max)
+		}
+	case map[string]interface{}:
+		for key, element := range v {
+			if s, ok := element.(string); ok && isDescriptionKey(key) {
+				v[key] = truncateRunes(s, max)
+				continue
+			}
+			truncateDescriptions(element, max)
+		}
+	}
+}
+
+// isDescriptionKey reports whether an output key holds free-form description text
+func isDescriptionKey(key string) bool {
+	return key == "description" || strings.HasSuffix(key, "_description")
+}
+
+// truncateRunes shortens s to at most max characters, marking the cut with an ellipsis
+func truncateRunes(s string, max int) string {
+	if max <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max]) + "…"
+}
+
+// videoFieldPaths maps video output keys onto the video resource paths they are built from
+var videoFieldPaths = map[string]string{
+	"video_id":                   "id",
+	"title":                      "snippet/title",
+	"description":                "snippet/description",
+	"channel_id":                 "snippet/channelId",
+	"channel_title":              "snippet/channelTitle",
+	"published_at":               "snippet/publishedAt",
+	"thumbnail_url":              "snippet/thumbnails",
+	"thumbnails":                 "snippet/thumbnails",
+	"tags":                       "snippet/tags",
+	"category_id":                "snippet/categoryId",
+	"category_name":              "snippet/categoryId",
+	"live_broadcast_content":     "snippet/liveBroadcastContent",
+	"default_language":           "snippet/defaultLanguage",
+	"default_audio_language":     "snippet/defaultAudioLanguage",
+	"localized_title":            "snippet/localized",
+	"localized_description":      "snippet/localized",
+	"duration":                   "contentDetails/duration",
+	"definition":                 "contentDetails/definition",
+	"dimension":                  "contentDetails/dimension",
+	"projection":                 "contentDetails/projection",
+	"caption":                    "contentDetails/caption",
+	"licensed_content":           "contentDetails/licensedContent",
+	"region_restriction":         "contentDetails/regionRestriction",
+	"content_rating":             "contentDetails/contentRating",
+	"view_count":                 "statistics/viewCount",
+	"like_count":                 "statistics/likeCount",
+	"comment_count":              "statistics/commentCount",
+	"favorite_count":             "statistics/favoriteCount",
+	"status":                     "status",
+	"topics":                     "topicDetails",
+	"topic_urls":                 "topicDetails",
+	"topic_ids":                  "topicDetails",
+	"relevant_topic_ids":         "topicDetails",
+	"live_streaming":             "liveStreamingDetails",
+	"localizations":              "localizations",
+	"embed_html":                 "player",
+	"recording":                  "recordingDetails",
+	"has_paid_product_placement": "paidProductPlacementDetails",
+}
+
+// videoAPIFields builds the partial response selector for video list calls
+// returning the given output keys. It returns an empty selector, meaning the
+// full resource, if no keys are given or any key cannot be mapped.
+func videoAPIFields(keys []string, envelope ...string) googleapi.Field {
+	if len(keys) == 0 {
+		return ""
+	}
+
+	paths := []string{"id"}
+	for _, key := range keys {
+		path, ok := videoFieldPaths[key]
+		if !ok {
+			return ""
+		}
+		if !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+
+	selector := append(envelope, "items("+strings.Join(paths, ",")+")")
+	return googleapi.Field(strings.Join(selector, ","))
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// decodeJSON parses a JSON literal into generic values, as the middleware sees tool output
+func decodeJSON(t *testing.T, s string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		t.Fatalf("bad test JSON %s: %v", s, err)
+	}
+	return value
+}
+
+func TestShapeOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		itemKeys []string
+		opts     outputOptions
+		want     string
+	}{
+		{
+			name:   "no options leave the output alone",
+			output: `{"video_id":"a","title":"T","description":"long"}`,
+			want:   `{"video_id":"a","title":"T","description":"long"}`,
+		},
+		{
+			name:   "single object",
+			output: `{"video_id":"a","title":"T","view_count":5}`,
+			opts:   outputOptions{Fields: []string{"title"}},
+			want:   `{"title":"T"}`,
+		},
+		{
+			name:   "list of objects",
+			output: `[{"channel_id":"c1","title":"One"},{"channel_id":"c2","title":"Two"}]`,
+			opts:   outputOptions{Fields: []string{"channel_id"}},
+			want:   `[{"channel_id":"c1"},{"channel_id":"c2"}]`,
+		},
+		{
+			name:     "envelope keys are kept and items projected",
+			output:   `{"next_page_token":"tok","total_results":2,"playlists":[{"playlist_id":"p","title":"P","item_count":3}]}`,
+			itemKeys: []string{"playlists"},
+			opts:     outputOptions{Fields: []string{"title"}},
+			want:     `{"next_page_token":"tok","total_results":2,"playlists":[{"title":"P"}]}`,
+		},
+		{
+			name:     "an envelope key among the fields does not project the envelope",
+			output:   `{"next_page_token":"tok","region_code":"US","videos":[{"video_id":"a","title":"T"}]}`,
+			itemKeys: []string{"videos"},
+			opts:     outputOptions{Fields: []string{"next_page_token", "title"}},
+			want:     `{"next_page_token":"tok","region_code":"US","videos":[{"title":"T"}]}`,
+		},
+		{
+			name:     "an item without the fields becomes empty",
+			output:   `{"videos":[{"video_id":"a","title":"T"},{"video_id":"b"}]}`,
+			itemKeys: []string{"videos"},
+			opts:     outputOptions{Fields: []string{"title"}},
+			want:     `{"videos":[{"title":"T"},{}]}`,
+		},
+		{
+			name:   "a single object without the fields becomes empty",
+			output: `{"video_id":"a"}`,
+			opts:   outputOptions{Fields: []string{"title"}},
+			want:   `{}`,
+		},
+		{
+			name:     "several item keys, some missing",
+			output:   `{"live_chat_id":"l","messages":[{"author":"x","text":"hi"}],"super_chats":[{"author":"y","amount":"$5"}]}`,
+			itemKeys: []string{"messages", "super_chats", "membership_events", "other_events"},
+			opts:     outputOptions{Fields: []string{"author"}},
+			want:     `{"live_chat_id":"l","messages":[{"author":"x"}],"super_chats":[{"author":"y"}]}`,
+		},
+		{
+			name:   "nested values of kept fields are not projected",
+			output: `{"title":"T","thumbnails":{"default":{"url":"u"}}}`,
+			opts:   outputOptions{Fields: []string{"thumbnails"}},
+			want:   `{"thumbnails":{"default":{"url":"u"}}}`,
+		},
+		{
+			name:   "descriptions are truncated at every depth",
+			output: `{"description":"abcdef","channel":{"channel_description":"ghijkl"},"items":[{"description":"mnopqr","title":"stuvwx"}]}`,
+			opts:   outputOptions{MaxDescriptionChars: 3},
+			want:   `{"description":"abc…","channel":{"channel_description":"ghi…"},"items":[{"description":"mno…","title":"stuvwx"}]}`,
+		},
+		{
+			name:     "projection and truncation together",
+			output:   `{"next_page_token":"tok","videos":[{"video_id":"a","description":"abcdef"}]}`,
+			itemKeys: []string{"videos"},
+			opts:     outputOptions{Fields: []string{"description"}, MaxDescriptionChars: 2},
+			want:     `{"next_page_token":"tok","videos":[{"description":"ab…"}]}`,
+		},
+		{
+			name:   "non-object items are kept",
+			output: `["US","DE"]`,
+			opts:   outputOptions{Fields: []string{"title"}},
+			want:   `["US","DE"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shapeOutput(decodeJSON(t, tt.output), tt.itemKeys, tt.opts)
+			if want := decodeJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				gotJSON, _ := json.Marshal(got)
+				t.Errorf("shapeOutput = %s, want %s", gotJSON, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		s    string
+		max  int
+		want string
+	}{
+		{"hello", 0, "hello"},
+		{"hello", -1, "hello"},
+		{"hello", 5, "hello"},
+		{"hello", 10, "hello"},
+		{"hello", 3, "hel…"},
+		{"", 3, ""},
+		{"héllo wörld", 7, "héllo w…"},
+		{"日本語のテキスト", 3, "日本語…"},
+		{"🎵🎶🎸", 2, "🎵🎶…"},
+	}
+	for _, tt := range tests {
+		if got := truncateRunes(tt.s, tt.max); got != tt.want {
+			t.Errorf("truncateRunes(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestTakeOutputOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     string
+		want     outputOptions
+		wantArgs string
+		wantErr  bool
+	}{
+		{
+			name:     "both options",
+			args:     `{"video_id":"a","fields":["title"],"max_description_chars":10}`,
+			want:     outputOptions{Fields: []string{"title"}, MaxDescriptionChars: 10},
+			wantArgs: `{"video_id":"a"}`,
+		},
+		{
+			name:     "no options",
+			args:     `{"video_id":"a"}`,
+			wantArgs: `{"video_id":"a"}`,
+		},
+		{
+			name:    "bad fields",
+			args:    `{"fields":"title"}`,
+			wantErr: true,
+		},
+		{
+			name:    "bad max_description_chars",
+			args:    `{"max_description_chars":"ten"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &mcp.CallToolParams{Name: "get_video_details", Arguments: json.RawMessage(tt.args)}
+			opts, err := takeOutputOptions(params)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("takeOutputOptions: %v", err)
+			}
+			if !reflect.DeepEqual(opts, tt.want) {
+				t.Errorf("options = %+v, want %+v", opts, tt.want)
+			}
+			if got := string(params.Arguments.(json.RawMessage)); got != tt.wantArgs {
+				t.Errorf("arguments = %s, want %s", got, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestOutputOptionsMiddleware(t *testing.T) {
+	type echoArgs struct {
+		VideoID string `json:"video_id"`
+	}
+
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	server.AddReceivingMiddleware(outputOptionsMiddleware)
+
+	var seen outputOptions
+	mcp.AddTool(server, &mcp.Tool{Name: "list_playlists"}, func(ctx context.Context, req *mcp.CallToolRequest, args echoArgs) (*mcp.CallToolResult, any, error) {
+		seen = outputOptionsFrom(ctx)
+		return jsonResult(map[string]interface{}{
+			"next_page_token": "tok",
+			"playlists": []map[string]interface{}{
+				{"playlist_id": args.VideoID, "title": "T", "description": "abcdef"},
+			},
+		})
+	})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	tools, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := tools.Tools[0].InputSchema.Properties
+	for _, name := range []string{"video_id", "fields", "max_description_chars"} {
+		if properties[name] == nil {
+			t.Errorf("input schema has no %s property", name)
+		}
+	}
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "list_playlists",
+		Arguments: map[string]interface{}{"video_id": "p1", "fields": []string{"playlist_id", "description"}, "max_description_chars": 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.IsError {
+		t.Fatalf("tool call failed: %v", result.Content[0].(*mcp.TextContent).Text)
+	}
+
+	got := decodeJSON(t, result.Content[0].(*mcp.TextContent).Text)
+	want := decodeJSON(t, `{"next_page_token":"tok","playlists":[{"playlist_id":"p1","description":"ab…"}]}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("output = %v, want %v", got, want)
+	}
+	if seen.MaxDescriptionChars != 2 || len(seen.Fields) != 2 {
+		t.Errorf("handler saw options %+v", seen)
+	}
+}
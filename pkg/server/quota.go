@@ -0,0 +1,137 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// quotaResetLocation is the time zone in which the YouTube daily quota resets
+var quotaResetLocation = func() *time.Location {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		return time.FixedZone("PST", -8*60*60)
+	}
+	return loc
+}()
+
+// nextQuotaReset returns the next midnight Pacific Time after now
+func nextQuotaReset(now time.Time) time.Time {
+	local := now.In(quotaResetLocation)
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, quotaResetLocation)
+}
+
+// apiErrorReason extracts the first error reason from a YouTube API error body
+func apiErrorReason(body []byte) string {
+	var payload struct {
+		Error struct {
+			Errors []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || len(payload.Error.Errors) == 0 {
+		return ""
+	}
+	return payload.Error.Errors[0].Reason
+}
+
+// isQuotaReason reports whether the reason means the daily quota is used up
+func isQuotaReason(reason string) bool {
+	return reason == "quotaExceeded" || reason == "dailyLimitExceeded"
+}
+
+// readErrorReason reads the reason from a failed response and restores its body
+func readErrorReason(resp *http.Response) string {
+	if resp.StatusCode < 400 || resp.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	return apiErrorReason(body)
+}
+
+// healthTransport tracks the health of a single account's credentials. Once the
+// account's quota is exhausted, requests fail fast until the daily reset.
+type healthTransport struct {
+	base http.RoundTripper
+
+	mu             sync.Mutex
+	requests       int64
+	exhaustedUntil time.Time
+	lastError      string
+	lastErrorAt    time.Time
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *healthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	if until := t.exhaustedUntil; time.Now().Before(until) {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("quota exhausted for this account until %s", until.Format(time.RFC3339))
+	}
+	t.requests++
+	t.mu.Unlock()
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.recordError(err.Error())
+		return nil, err
+	}
+
+	if reason := readErrorReason(resp); reason != "" {
+		t.recordError(reason)
+		if isQuotaReason(reason) && t.accountExhausted() {
+			t.mu.Lock()
+			t.exhaustedUntil = nextQuotaReset(time.Now())
+			t.mu.Unlock()
+		}
+	}
+
+	return resp, nil
+}
+
+// accountExhausted reports whether a quota error means the whole account is out
+// of quota. A key pool passes one through for a single key when it cannot retry,
+// e.g. for a request body it cannot replay, while other keys may still be left.
+func (t *healthTransport) accountExhausted() bool {
+	if pool, ok := t.base.(*keyPool); ok {
+		return !pool.hasAvailable(nil)
+	}
+	return true
+}
+
+func (t *healthTransport) recordError(msg string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastError = msg
+	t.lastErrorAt = time.Now()
+}
+
+// snapshot returns the current health as tool output fields
+func (t *healthTransport) snapshot() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := map[string]interface{}{
+		"status":   "ok",
+		"requests": t.requests,
+	}
+	if time.Now().Before(t.exhaustedUntil) {
+		status["status"] = "quota_exhausted"
+		status["quota_resets_at"] = t.exhaustedUntil.Format(time.RFC3339)
+	}
+	if t.lastError != "" {
+		status["last_error"] = t.lastError
+		status["last_error_at"] = t.lastErrorAt.Format(time.RFC3339)
+	}
+	return status
+}
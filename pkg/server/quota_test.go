@@ -0,0 +1,144 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNextQuotaReset(t *testing.T) {
+	pacific := quotaResetLocation
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "morning resets at the coming midnight",
+			now:  time.Date(2025, 3, 4, 9, 30, 0, 0, pacific),
+			want: time.Date(2025, 3, 5, 0, 0, 0, 0, pacific),
+		},
+		{
+			name: "exactly midnight waits a full day",
+			now:  time.Date(2025, 3, 4, 0, 0, 0, 0, pacific),
+			want: time.Date(2025, 3, 5, 0, 0, 0, 0, pacific),
+		},
+		{
+			name: "UTC time is converted to Pacific first",
+			now:  time.Date(2025, 3, 5, 3, 0, 0, 0, time.UTC), // 19:00 on March 4 in Pacific time
+			want: time.Date(2025, 3, 5, 0, 0, 0, 0, pacific),
+		},
+		{
+			name: "end of month rolls over",
+			now:  time.Date(2025, 1, 31, 23, 59, 0, 0, pacific),
+			want: time.Date(2025, 2, 1, 0, 0, 0, 0, pacific),
+		},
+		{
+			name: "day of the daylight saving switch",
+			now:  time.Date(2025, 3, 9, 12, 0, 0, 0, pacific),
+			want: time.Date(2025, 3, 10, 0, 0, 0, 0, pacific),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextQuotaReset(tt.now)
+			if !got.Equal(tt.want) {
+				t.Errorf("nextQuotaReset(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+			if !got.After(tt.now) {
+				t.Errorf("reset %v is not after %v", got, tt.now)
+			}
+		})
+	}
+}
+
+func TestAPIErrorReason(t *testing.T) {
+	tests := map[string]string{
+		quotaBody: "quotaExceeded",
+		`{"error":{"errors":[{"reason":"dailyLimitExceeded"},{"reason":"other"}]}}`: "dailyLimitExceeded",
+		`{"error":{"errors":[]}}`: "",
+		`not json`:                "",
+	}
+	for body, want := range tests {
+		if got := apiErrorReason([]byte(body)); got != want {
+			t.Errorf("apiErrorReason(%s) = %q, want %q", body, got, want)
+		}
+	}
+}
+
+func TestHealthTransportQuotaExhaustion(t *testing.T) {
+	quotaError := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(quotaBody))}, nil
+	})
+
+	// A request body the pool cannot replay is not retried with the next key
+	unreplayable := func() *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, "https://example.com/youtube/v3/videos", io.NopCloser(strings.NewReader("{}")))
+		req.GetBody = nil
+		return req
+	}
+
+	tests := []struct {
+		name          string
+		base          func(t *testing.T) http.RoundTripper
+		wantExhausted bool
+	}{
+		{
+			name:          "single credential",
+			base:          func(t *testing.T) http.RoundTripper { return quotaError },
+			wantExhausted: true,
+		},
+		{
+			name: "key pool with keys left",
+			base: func(t *testing.T) http.RoundTripper {
+				pool, err := newKeyPool([]string{"a", "b"}, KeyStrategyFailover, quotaError)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return pool
+			},
+		},
+		{
+			name: "key pool with every key used up",
+			base: func(t *testing.T) http.RoundTripper {
+				pool, err := newKeyPool([]string{"a"}, KeyStrategyFailover, quotaError)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return pool
+			},
+			wantExhausted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := &healthTransport{base: tt.base(t)}
+			resp, err := health.RoundTrip(unreplayable())
+			if err != nil {
+				t.Fatalf("RoundTrip: %v", err)
+			}
+			resp.Body.Close()
+
+			status := health.snapshot()
+			if exhausted := status["status"] == "quota_exhausted"; exhausted != tt.wantExhausted {
+				t.Errorf("account exhausted = %v, want %v (%v)", exhausted, tt.wantExhausted, status)
+			}
+			if status["last_error"] != "quotaExceeded" {
+				t.Errorf("last_error = %v, want quotaExceeded", status["last_error"])
+			}
+
+			resp, err = health.RoundTrip(unreplayable())
+			if err == nil {
+				resp.Body.Close()
+			}
+			if failedFast := err != nil; failedFast != tt.wantExhausted {
+				t.Errorf("next request failed fast = %v, want %v", failedFast, tt.wantExhausted)
+			}
+		})
+	}
+}
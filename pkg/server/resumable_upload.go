@@ -0,0 +1,265 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+)
+
+// statusResumeIncomplete is the status the upload server answers while an
+// upload session still expects more bytes
+const statusResumeIncomplete = 308
+
+// maxUploadRetryBackoff caps the wait between retries of a failed chunk
+const maxUploadRetryBackoff = 30 * time.Second
+
+// errUploadSessionExpired means an upload session can no longer be resumed
+var errUploadSessionExpired = errors.New("upload session has expired; start a new upload")
+
+// UploadInterruptedError reports an upload that failed part way. The session
+// stays valid for about a week, so the upload can be resumed from the bytes
+// the server acknowledged by passing SessionURI back.
+type UploadInterruptedError struct {
+	SessionURI string
+	Uploaded   int64
+	Total      int64
+	Err        error
+}
+
+func (e *UploadInterruptedError) Error() string {
+	return fmt.Sprintf("upload interrupted after %d of %d bytes: %v (resume with session URI %s)",
+		e.Uploaded, e.Total, e.Err, e.SessionURI)
+}
+
+func (e *UploadInterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// resumableUpload speaks the YouTube resumable upload protocol directly, rather
+// than through the generated client, so that the upload session URI can be
+// handed out and an interrupted upload resumed, even after a restart.
+type resumableUpload struct {
+	// client sends the requests; it must add the OAuth2 credentials
+	client *http.Client
+	// endpoint is the videos upload URL, e.g. https://youtube.googleapis.com/upload/youtube/v3/videos
+	endpoint  string
+	chunkSize int64
+	// retryDeadline is how long a failing chunk is retried before giving up
+	retryDeadline time.Duration
+	// retryBackoff is the first wait between retries, doubled on every retry
+	retryBackoff time.Duration
+	// progress, if not nil, is called after every chunk with the bytes
+	// acknowledged and the total size
+	progress func(current, total int64)
+}
+
+// start opens an upload session for a file of the given size and returns its URI
+func (u *resumableUpload) start(ctx context.Context, video *youtube.Video, parts []string, size int64, contentType string) (string, error) {
+	body, err := json.Marshal(video)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"uploadType": {"resumable"},
+		"part":       {strings.Join(parts, ",")},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.endpoint+"?"+query.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Upload-Content-Length", strconv.FormatInt(size, 10))
+	req.Header.Set("X-Upload-Content-Type", contentType)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return "", err
+	}
+
+	sessionURI := resp.Header.Get("Location")
+	if sessionURI == "" {
+		return "", fmt.Errorf("upload server returned no session URI")
+	}
+	return sessionURI, nil
+}
+
+// checkSession verifies that a session URI belongs to the upload endpoint, so
+// that the OAuth2 credentials are never sent to another host
+func (u *resumableUpload) checkSession(sessionURI string) error {
+	session, err := url.Parse(sessionURI)
+	if err != nil {
+		return fmt.Errorf("invalid upload session URI: %v", err)
+	}
+	endpoint, err := url.Parse(u.endpoint)
+	if err != nil {
+		return err
+	}
+	if session.Scheme != endpoint.Scheme || session.Host != endpoint.Host {
+		return fmt.Errorf("upload session URI must be on %s://%s", endpoint.Scheme, endpoint.Host)
+	}
+	return nil
+}
+
+// send uploads the file to the session, first asking how much of it the
+// session already has when resuming. A chunk failing with a network or server
+// error is retried, resuming from the acknowledged offset, until retryDeadline
+// passes without progress; the returned error is then an *UploadInterruptedError.
+func (u *resumableUpload) send(ctx context.Context, sessionURI string, file io.ReaderAt, size int64, resume bool) (*youtube.Video, error) {
+	var offset int64
+	var video *youtube.Video
+	var err error
+	if resume {
+		if offset, video, err = u.query(ctx, sessionURI, size); err != nil {
+			return nil, u.interrupted(sessionURI, 0, size, err)
+		}
+	}
+
+	lastProgress := time.Now()
+	backoff := u.retryBackoff
+	for video == nil {
+		acknowledged := offset
+		offset, video, err = u.sendChunk(ctx, sessionURI, file, offset, size)
+		if err == nil && video == nil && offset <= acknowledged {
+			err = fmt.Errorf("upload server acknowledged no new bytes")
+			offset = acknowledged
+		}
+		if err == nil {
+			lastProgress = time.Now()
+			backoff = u.retryBackoff
+			if u.progress != nil && video == nil {
+				u.progress(offset, size)
+			}
+			continue
+		}
+
+		if !isTransientUploadError(err) || ctx.Err() != nil || time.Since(lastProgress) > u.retryDeadline {
+			return nil, u.interrupted(sessionURI, acknowledged, size, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, u.interrupted(sessionURI, acknowledged, size, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxUploadRetryBackoff)
+
+		// Ask the server how much of the failed chunk arrived
+		offset, video, err = u.query(ctx, sessionURI, size)
+		if err != nil {
+			if !isTransientUploadError(err) {
+				return nil, u.interrupted(sessionURI, acknowledged, size, err)
+			}
+			offset = acknowledged
+		}
+	}
+
+	if u.progress != nil {
+		u.progress(size, size)
+	}
+	return video, nil
+}
+
+// query asks the session how many bytes it has received. The video is
+// returned instead if the upload is already complete.
+func (u *resumableUpload) query(ctx context.Context, sessionURI string, size int64) (int64, *youtube.Video, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, sessionURI, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+	return u.do(req)
+}
+
+// sendChunk uploads the next chunk starting at offset and returns the new offset
+func (u *resumableUpload) sendChunk(ctx context.Context, sessionURI string, file io.ReaderAt, offset, size int64) (int64, *youtube.Video, error) {
+	n := min(u.chunkSize, size-offset)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, sessionURI, io.NewSectionReader(file, offset, n))
+	if err != nil {
+		return offset, nil, err
+	}
+	req.ContentLength = n
+	if n > 0 {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+n-1, size))
+	} else {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+	}
+
+	next, video, err := u.do(req)
+	if err != nil {
+		return offset, nil, err
+	}
+	return next, video, nil
+}
+
+// do sends a session request and interprets the answer: the acknowledged
+// offset while incomplete, or the uploaded video once complete
+func (u *resumableUpload) do(req *http.Request) (int64, *youtube.Video, error) {
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		var video youtube.Video
+		if err := json.NewDecoder(resp.Body).Decode(&video); err != nil {
+			return 0, nil, fmt.Errorf("error decoding uploaded video: %v", err)
+		}
+		return 0, &video, nil
+	case statusResumeIncomplete:
+		return acknowledgedOffset(resp.Header.Get("Range")), nil, nil
+	case http.StatusNotFound, http.StatusGone:
+		return 0, nil, errUploadSessionExpired
+	}
+	return 0, nil, googleapi.CheckResponse(resp)
+}
+
+// acknowledgedOffset returns the offset following a "bytes=0-N" Range header.
+// Without the header, no bytes have been received yet.
+func acknowledgedOffset(header string) int64 {
+	_, last, ok := strings.Cut(strings.TrimPrefix(header, "bytes="), "-")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n + 1
+}
+
+// isTransientUploadError reports whether a failed upload request is worth retrying
+func isTransientUploadError(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= 500 || apiErr.Code == http.StatusTooManyRequests
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// interrupted wraps a failure so that the caller learns how to resume, unless
+// the session itself is gone
+func (u *resumableUpload) interrupted(sessionURI string, uploaded, total int64, err error) error {
+	if errors.Is(err, errUploadSessionExpired) {
+		return err
+	}
+	return &UploadInterruptedError{SessionURI: sessionURI, Uploaded: uploaded, Total: total, Err: err}
+}
@@ -0,0 +1,350 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// fakeUploadServer is a local stand-in for the YouTube upload endpoint that
+// speaks the resumable upload protocol
+type fakeUploadServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	metadata youtube.Video
+	query    string
+	size     int64
+	received []byte
+	// ranges records the Content-Range header of every chunk
+	ranges []string
+	// failChunk answers the chunks with these indexes with a 503, after
+	// keeping the given number of their bytes
+	failChunk map[int]int
+	// down answers every chunk with a 500
+	down bool
+}
+
+func newFakeUploadServer(t *testing.T) *fakeUploadServer {
+	t.Helper()
+	f := &fakeUploadServer{failChunk: map[int]int{}}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeUploadServer) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/upload/youtube/v3/videos":
+		if r.URL.Query().Get("uploadType") != "resumable" {
+			http.Error(w, "expected a resumable upload", http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&f.metadata); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.query = r.URL.RawQuery
+		f.size, _ = strconv.ParseInt(r.Header.Get("X-Upload-Content-Length"), 10, 64)
+		w.Header().Set("Location", f.URL+"/upload/session/1")
+
+	case r.Method == http.MethodPut && r.URL.Path == "/upload/session/1":
+		contentRange := r.Header.Get("Content-Range")
+		if strings.HasPrefix(contentRange, "bytes */") {
+			f.answer(w)
+			return
+		}
+
+		chunk := len(f.ranges)
+		f.ranges = append(f.ranges, contentRange)
+		var start int64
+		fmt.Sscanf(contentRange, "bytes %d-", &start)
+		if start != int64(len(f.received)) {
+			http.Error(w, "chunk does not continue the upload", http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+
+		if f.down {
+			http.Error(w, "backend error", http.StatusInternalServerError)
+			return
+		}
+		if keep, ok := f.failChunk[chunk]; ok {
+			f.received = append(f.received, body[:keep]...)
+			http.Error(w, "backend error", http.StatusServiceUnavailable)
+			return
+		}
+		f.received = append(f.received, body...)
+		f.answer(w)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// answer reports the session state: the acknowledged range while incomplete,
+// the video once every byte arrived
+func (f *fakeUploadServer) answer(w http.ResponseWriter) {
+	if int64(len(f.received)) < f.size {
+		if len(f.received) > 0 {
+			w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(f.received)-1))
+		}
+		w.WriteHeader(statusResumeIncomplete)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(&youtube.Video{
+		Id:      "uploaded123",
+		Snippet: f.metadata.Snippet,
+		Status:  &youtube.VideoStatus{UploadStatus: "uploaded"},
+	})
+}
+
+func (f *fakeUploadServer) uploader(chunkSize int64, progress func(current, total int64)) *resumableUpload {
+	return &resumableUpload{
+		client:        f.Client(),
+		endpoint:      f.URL + "/upload/youtube/v3/videos",
+		chunkSize:     chunkSize,
+		retryDeadline: time.Minute,
+		retryBackoff:  time.Millisecond,
+		progress:      progress,
+	}
+}
+
+// progressLog records progress callbacks as "current/total"
+type progressLog []string
+
+func (p *progressLog) record(current, total int64) {
+	*p = append(*p, fmt.Sprintf("%d/%d", current, total))
+}
+
+// startUpload opens a session for content on the fake server
+func startUpload(t *testing.T, u *resumableUpload, content []byte) string {
+	t.Helper()
+	video := &youtube.Video{Snippet: &youtube.VideoSnippet{Title: "Test upload"}}
+	sessionURI, err := u.start(context.Background(), video, []string{"snippet", "status"}, int64(len(content)), "video/mp4")
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	return sessionURI
+}
+
+func TestResumableUploadChunks(t *testing.T) {
+	server := newFakeUploadServer(t)
+	content := []byte("0123456789")
+	var progress progressLog
+	u := server.uploader(4, progress.record)
+
+	sessionURI := startUpload(t, u, content)
+	video, err := u.send(context.Background(), sessionURI, bytes.NewReader(content), int64(len(content)), false)
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	if video.Id != "uploaded123" || video.Snippet.Title != "Test upload" {
+		t.Errorf("video = %+v, want the uploaded video with its metadata", video)
+	}
+	if !bytes.Equal(server.received, content) {
+		t.Errorf("server received %q, want %q", server.received, content)
+	}
+	if want := []string{"bytes 0-3/10", "bytes 4-7/10", "bytes 8-9/10"}; !slices.Equal(server.ranges, want) {
+		t.Errorf("chunks = %v, want %v", server.ranges, want)
+	}
+	if want := (progressLog{"4/10", "8/10", "10/10"}); !slices.Equal(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+	if want := "part=snippet%2Cstatus&uploadType=resumable"; server.query != want {
+		t.Errorf("query = %q, want %q", server.query, want)
+	}
+}
+
+func TestResumableUploadRetriesTransientErrors(t *testing.T) {
+	server := newFakeUploadServer(t)
+	// The second chunk fails after two of its bytes arrived
+	server.failChunk[1] = 2
+	content := []byte("0123456789")
+	var progress progressLog
+	u := server.uploader(4, progress.record)
+
+	sessionURI := startUpload(t, u, content)
+	if _, err := u.send(context.Background(), sessionURI, bytes.NewReader(content), int64(len(content)), false); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	if !bytes.Equal(server.received, content) {
+		t.Errorf("server received %q, want %q", server.received, content)
+	}
+	if want := []string{"bytes 0-3/10", "bytes 4-7/10", "bytes 6-9/10"}; !slices.Equal(server.ranges, want) {
+		t.Errorf("chunks = %v, want %v", server.ranges, want)
+	}
+	if want := (progressLog{"4/10", "10/10"}); !slices.Equal(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+}
+
+func TestResumableUploadResumesAfterFailure(t *testing.T) {
+	server := newFakeUploadServer(t)
+	content := []byte("0123456789")
+	u := server.uploader(4, nil)
+	u.retryDeadline = 0
+
+	sessionURI := startUpload(t, u, content)
+	server.failChunk[1] = 0
+	server.failChunk[2] = 0
+
+	_, err := u.send(context.Background(), sessionURI, bytes.NewReader(content), int64(len(content)), false)
+	var interrupted *UploadInterruptedError
+	if !errors.As(err, &interrupted) {
+		t.Fatalf("send error = %v, want an UploadInterruptedError", err)
+	}
+	if interrupted.SessionURI != sessionURI || interrupted.Uploaded != 4 || interrupted.Total != 10 {
+		t.Errorf("interrupted = %+v, want session %s after 4 of 10 bytes", interrupted, sessionURI)
+	}
+
+	// A new uploader, as after a restart, picks up where the session left off
+	server.failChunk = map[int]int{}
+	var progress progressLog
+	resumed := server.uploader(4, progress.record)
+	if err := resumed.checkSession(interrupted.SessionURI); err != nil {
+		t.Fatalf("checkSession: %v", err)
+	}
+	video, err := resumed.send(context.Background(), interrupted.SessionURI, bytes.NewReader(content), int64(len(content)), true)
+	if err != nil {
+		t.Fatalf("resumed send: %v", err)
+	}
+
+	if video.Id != "uploaded123" {
+		t.Errorf("video id = %q, want uploaded123", video.Id)
+	}
+	if !bytes.Equal(server.received, content) {
+		t.Errorf("server received %q, want %q", server.received, content)
+	}
+	if want := []string{"bytes 0-3/10", "bytes 4-7/10", "bytes 4-7/10", "bytes 8-9/10"}; !slices.Equal(server.ranges, want) {
+		t.Errorf("chunks = %v, want %v", server.ranges, want)
+	}
+	if want := (progressLog{"8/10", "10/10"}); !slices.Equal(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+}
+
+func TestResumableUploadGivesUpAfterDeadline(t *testing.T) {
+	server := newFakeUploadServer(t)
+	server.down = true
+	content := []byte("0123456789")
+	u := server.uploader(4, nil)
+	u.retryDeadline = 20 * time.Millisecond
+
+	sessionURI := startUpload(t, u, content)
+	_, err := u.send(context.Background(), sessionURI, bytes.NewReader(content), int64(len(content)), false)
+	var interrupted *UploadInterruptedError
+	if !errors.As(err, &interrupted) || interrupted.Uploaded != 0 {
+		t.Fatalf("send error = %v, want an UploadInterruptedError after 0 bytes", err)
+	}
+	if len(server.ranges) < 2 {
+		t.Errorf("chunk was sent %d times, want retries", len(server.ranges))
+	}
+}
+
+func TestResumableUploadCheckSession(t *testing.T) {
+	u := &resumableUpload{endpoint: "https://youtube.googleapis.com/upload/youtube/v3/videos"}
+
+	tests := map[string]bool{
+		"https://youtube.googleapis.com/upload/youtube/v3/videos?upload_id=abc": true,
+		"http://youtube.googleapis.com/upload/youtube/v3/videos?upload_id=abc":  false,
+		"https://attacker.example.com/upload?upload_id=abc":                     false,
+		"://not a url": false,
+	}
+	for sessionURI, valid := range tests {
+		if err := u.checkSession(sessionURI); (err == nil) != valid {
+			t.Errorf("checkSession(%q) error = %v, want valid %v", sessionURI, err, valid)
+		}
+	}
+}
+
+func TestAcknowledgedOffset(t *testing.T) {
+	tests := map[string]int64{
+		"":             0,
+		"bytes=0-0":    1,
+		"bytes=0-4095": 4096,
+		"bytes=0-x":    0,
+	}
+	for header, want := range tests {
+		if got := acknowledgedOffset(header); got != want {
+			t.Errorf("acknowledgedOffset(%q) = %d, want %d", header, got, want)
+		}
+	}
+}
+
+func TestUploadVideo(t *testing.T) {
+	server := newFakeUploadServer(t)
+
+	cfg := &Config{APIEndpoint: server.URL + "/", UploadChunkSizeMB: 1}
+	service, err := newService(context.Background(), cfg, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("newService: %v", err)
+	}
+	yc := &YouTubeClient{config: cfg, authService: service, authClient: server.Client()}
+
+	content := bytes.Repeat([]byte("video"), (5<<20)/10) // 2.5 MiB
+	path := filepath.Join(t.TempDir(), "clip.mp4")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	madeForKids := false
+	var progress progressLog
+	video, err := yc.UploadVideo(context.Background(), VideoUpload{
+		FilePath:      path,
+		Title:         "My clip",
+		Tags:          []string{"test"},
+		PrivacyStatus: "private",
+		MadeForKids:   &madeForKids,
+	}, progress.record)
+	if err != nil {
+		t.Fatalf("UploadVideo: %v", err)
+	}
+
+	if video.Id != "uploaded123" {
+		t.Errorf("video id = %q, want uploaded123", video.Id)
+	}
+	if server.metadata.Snippet.Title != "My clip" || server.metadata.Status.PrivacyStatus != "private" {
+		t.Errorf("metadata = %+v %+v, want the upload's title and privacy", server.metadata.Snippet, server.metadata.Status)
+	}
+	if !bytes.Equal(server.received, content) {
+		t.Errorf("server received %d bytes, want %d", len(server.received), len(content))
+	}
+	if len(server.ranges) != 3 {
+		t.Errorf("sent %d chunks, want 3: %v", len(server.ranges), server.ranges)
+	}
+	if len(progress) != 3 || progress[2] != fmt.Sprintf("%d/%d", len(content), len(content)) {
+		t.Errorf("progress = %v, want 3 updates ending at the file size", progress)
+	}
+
+	// Resuming checks the session URI before sending any credentials
+	_, err = yc.UploadVideo(context.Background(), VideoUpload{
+		FilePath:   path,
+		SessionURI: "https://attacker.example.com/upload?upload_id=abc",
+	}, nil)
+	if err == nil || !strings.Contains(err.Error(), "upload session URI must be on") {
+		t.Errorf("UploadVideo with a foreign session URI error = %v", err)
+	}
+}
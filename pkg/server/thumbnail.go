@@ -0,0 +1,174 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // register the PNG decoder
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Custom thumbnail limits enforced by YouTube
+const (
+	maxThumbnailBytes  = 2 << 20
+	minThumbnailWidth  = 640
+	thumbnailMaxWidth  = 1280
+	thumbnailMaxHeight = 720
+)
+
+// thumbnailImage is a validated thumbnail ready to upload
+type thumbnailImage struct {
+	data        []byte
+	contentType string
+	width       int
+	height      int
+	// resizedPath is set when a resized copy was generated
+	resizedPath string
+}
+
+// prepareThumbnail checks a JPEG or PNG file against the thumbnail limits
+// before any quota is spent. If the file is too large and resize is set, a
+// copy scaled to fit 1280x720 is written to a temporary file and used instead;
+// the original and its directory are never modified.
+func prepareThumbnail(path string, resize bool) (*thumbnailImage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading thumbnail: %v", err)
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("thumbnail must be a JPEG or PNG image: %v", err)
+	}
+	if format != "jpeg" && format != "png" {
+		return nil, fmt.Errorf("thumbnail must be a JPEG or PNG image, got %s", format)
+	}
+	if cfg.Width < minThumbnailWidth {
+		return nil, fmt.Errorf("thumbnail is %dx%d; it must be at least %d pixels wide (%dx%d recommended)",
+			cfg.Width, cfg.Height, minThumbnailWidth, thumbnailMaxWidth, thumbnailMaxHeight)
+	}
+
+	thumb := &thumbnailImage{
+		data:        data,
+		contentType: "image/" + format,
+		width:       cfg.Width,
+		height:      cfg.Height,
+	}
+	if len(data) <= maxThumbnailBytes {
+		return thumb, nil
+	}
+
+	if !resize {
+		return nil, fmt.Errorf("thumbnail is %d bytes, over the 2MB limit; set resize to upload a scaled-down copy", len(data))
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding thumbnail: %v", err)
+	}
+
+	scaled := scaleToFit(img, thumbnailMaxWidth, thumbnailMaxHeight)
+	bounds := scaled.Bounds()
+	if bounds.Dx() < minThumbnailWidth {
+		return nil, fmt.Errorf("thumbnail is %dx%d; scaled to fit %dx%d it would be %dx%d, under the %d pixel minimum width. Crop it closer to 16:9 first",
+			cfg.Width, cfg.Height, thumbnailMaxWidth, thumbnailMaxHeight, bounds.Dx(), bounds.Dy(), minThumbnailWidth)
+	}
+
+	encoded, err := encodeJPEGUnder(scaled, maxThumbnailBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	resizedPath, err := writeTempThumbnail(path, encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return &thumbnailImage{
+		data:        encoded,
+		contentType: "image/jpeg",
+		width:       bounds.Dx(),
+		height:      bounds.Dy(),
+		resizedPath: resizedPath,
+	}, nil
+}
+
+// writeTempThumbnail saves a resized copy of the thumbnail at path to a new
+// temporary file, so that no existing file is ever overwritten
+func writeTempThumbnail(path string, data []byte) (string, error) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	file, err := os.CreateTemp("", name+".resized-*.jpg")
+	if err != nil {
+		return "", fmt.Errorf("error saving resized thumbnail: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("error saving resized thumbnail: %v", err)
+	}
+	return file.Name(), nil
+}
+
+// encodeJPEGUnder encodes img as JPEG at the highest quality that fits in maxBytes
+func encodeJPEGUnder(img image.Image, maxBytes int) ([]byte, error) {
+	for quality := 92; quality >= 50; quality -= 7 {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, fmt.Errorf("error encoding thumbnail: %v", err)
+		}
+		if buf.Len() <= maxBytes {
+			return buf.Bytes(), nil
+		}
+	}
+	return nil, fmt.Errorf("could not compress thumbnail under %d bytes", maxBytes)
+}
+
+// scaleToFit shrinks img to fit within maxWidth x maxHeight, keeping its aspect
+// ratio, by averaging the source pixels covered by each target pixel
+func scaleToFit(img image.Image, maxWidth, maxHeight int) image.Image {
+	src := img.Bounds()
+	width, height := src.Dx(), src.Dy()
+	if width <= maxWidth && height <= maxHeight {
+		return img
+	}
+
+	if width*maxHeight > height*maxWidth {
+		height = max(1, height*maxWidth/width)
+		width = maxWidth
+	} else {
+		width = max(1, width*maxHeight/height)
+		height = maxHeight
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := src.Min.Y + y*src.Dy()/height
+		y1 := max(y0+1, src.Min.Y+(y+1)*src.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := src.Min.X + x*src.Dx()/width
+			x1 := max(x0+1, src.Min.X+(x+1)*src.Dx()/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeNoisePNG writes a width x height PNG of random pixels, which compresses
+// badly and so produces large files
+func writeNoisePNG(t *testing.T, dir string, width, height int) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	rng := rand.New(rand.NewSource(1))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{uint8(rng.Intn(256)), uint8(rng.Intn(256)), uint8(rng.Intn(256)), 255})
+		}
+	}
+
+	path := filepath.Join(dir, "thumb.png")
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeJPEG writes a small solid-colour JPEG
+func writeJPEG(t *testing.T, dir string, width, height int) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	path := filepath.Join(dir, "thumb.jpg")
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPrepareThumbnailValidation(t *testing.T) {
+	dir := t.TempDir()
+
+	notImage := filepath.Join(dir, "notes.txt")
+	os.WriteFile(notImage, []byte("not an image"), 0o600)
+	if _, err := prepareThumbnail(notImage, false); err == nil || !strings.Contains(err.Error(), "JPEG or PNG") {
+		t.Errorf("non-image error = %v", err)
+	}
+
+	narrow := writeJPEG(t, t.TempDir(), 320, 180)
+	if _, err := prepareThumbnail(narrow, true); err == nil || !strings.Contains(err.Error(), "at least 640 pixels wide") {
+		t.Errorf("narrow image error = %v", err)
+	}
+
+	ok := writeJPEG(t, t.TempDir(), 1280, 720)
+	thumb, err := prepareThumbnail(ok, false)
+	if err != nil {
+		t.Fatalf("prepareThumbnail: %v", err)
+	}
+	if thumb.contentType != "image/jpeg" || thumb.width != 1280 || thumb.height != 720 || thumb.resizedPath != "" {
+		t.Errorf("thumb = %s %dx%d resized %q, want image/jpeg 1280x720 as is", thumb.contentType, thumb.width, thumb.height, thumb.resizedPath)
+	}
+}
+
+func TestPrepareThumbnailResize(t *testing.T) {
+	dir := t.TempDir()
+	path := writeNoisePNG(t, dir, 1920, 1080)
+
+	if _, err := prepareThumbnail(path, false); err == nil || !strings.Contains(err.Error(), "over the 2MB limit") {
+		t.Fatalf("oversized image without resize error = %v", err)
+	}
+
+	thumb, err := prepareThumbnail(path, true)
+	if err != nil {
+		t.Fatalf("prepareThumbnail: %v", err)
+	}
+	defer os.Remove(thumb.resizedPath)
+
+	if thumb.width != 1280 || thumb.height != 720 || thumb.contentType != "image/jpeg" {
+		t.Errorf("resized thumb = %s %dx%d, want image/jpeg 1280x720", thumb.contentType, thumb.width, thumb.height)
+	}
+	if len(thumb.data) > maxThumbnailBytes {
+		t.Errorf("resized thumb is %d bytes, over the limit", len(thumb.data))
+	}
+
+	// The copy goes to a temporary file; the original's directory is untouched
+	if filepath.Dir(thumb.resizedPath) == dir {
+		t.Errorf("resized copy %s was written next to the original", thumb.resizedPath)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("original directory has %d entries, want only the original", len(entries))
+	}
+	saved, err := os.ReadFile(thumb.resizedPath)
+	if err != nil || !bytes.Equal(saved, thumb.data) {
+		t.Errorf("resized copy does not hold the uploaded data (err %v)", err)
+	}
+}
+
+func TestPrepareThumbnailResizeTooNarrow(t *testing.T) {
+	// Scaling a tall image to fit 720 pixels high leaves it far under 640 wide
+	path := writeNoisePNG(t, t.TempDir(), 800, 1800)
+
+	_, err := prepareThumbnail(path, true)
+	if err == nil || !strings.Contains(err.Error(), "under the 640 pixel minimum width") {
+		t.Errorf("tall image error = %v", err)
+	}
+}
+
+func TestScaleToFit(t *testing.T) {
+	tests := []struct {
+		width, height int
+		wantW, wantH  int
+	}{
+		{1280, 720, 1280, 720},
+		{640, 360, 640, 360},
+		{1920, 1080, 1280, 720},
+		{2560, 1080, 1280, 540},
+		{1000, 1000, 720, 720},
+		{800, 1800, 320, 720},
+	}
+	for _, tt := range tests {
+		img := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+		bounds := scaleToFit(img, thumbnailMaxWidth, thumbnailMaxHeight).Bounds()
+		if bounds.Dx() != tt.wantW || bounds.Dy() != tt.wantH {
+			t.Errorf("scaleToFit(%dx%d) = %dx%d, want %dx%d", tt.width, tt.height, bounds.Dx(), bounds.Dy(), tt.wantW, tt.wantH)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// ListActivities lists the activities of a channel, or of the authenticated
+// user when mine is set, optionally bounded by publish time
+func (yc *YouTubeClient) ListActivities(channelID string, mine bool, publishedAfter, publishedBefore time.Time, maxResults int64, pageToken string) (*youtube.ActivityListResponse, error) {
+	service, err := yc.serviceFor(mine)
+	if err != nil {
+		return nil, err
+	}
+
+	call := service.Activities.List([]string{"snippet", "contentDetails"}).
+		MaxResults(maxResults)
+
+	if mine {
+		call = call.Mine(true)
+	} else {
+		call = call.ChannelId(channelID)
+	}
+	if !publishedAfter.IsZero() {
+		call = call.PublishedAfter(publishedAfter.Format(time.RFC3339))
+	}
+	if !publishedBefore.IsZero() {
+		call = call.PublishedBefore(publishedBefore.Format(time.RFC3339))
+	}
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+
+	response, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing activities: %v", err)
+	}
+
+	return response, nil
+}
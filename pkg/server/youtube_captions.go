@@ -0,0 +1,118 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+)
+
+// UploadCaption uploads a caption track for a video
+func (yc *YouTubeClient) UploadCaption(videoID, language, name string, isDraft bool, data []byte) (*youtube.Caption, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	caption := &youtube.Caption{
+		Snippet: &youtube.CaptionSnippet{
+			VideoId:  videoID,
+			Language: language,
+			Name:     name,
+			IsDraft:  isDraft,
+		},
+	}
+
+	created, err := service.Captions.Insert([]string{"snippet"}, caption).
+		Media(bytes.NewReader(data), googleapi.ContentType("application/octet-stream")).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("error uploading caption: %v", err)
+	}
+
+	return created, nil
+}
+
+// UpdateCaption changes the draft status of a caption track and, if data is
+// not nil, replaces its content
+func (yc *YouTubeClient) UpdateCaption(captionID string, isDraft *bool, data []byte) (*youtube.Caption, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	caption := &youtube.Caption{
+		Id:      captionID,
+		Snippet: &youtube.CaptionSnippet{},
+	}
+	if isDraft != nil {
+		caption.Snippet.IsDraft = *isDraft
+		caption.Snippet.ForceSendFields = []string{"IsDraft"}
+	}
+
+	call := service.Captions.Update([]string{"snippet"}, caption)
+	if data != nil {
+		call = call.Media(bytes.NewReader(data), googleapi.ContentType("application/octet-stream"))
+	}
+
+	updated, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error updating caption: %v", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteCaption deletes a caption track
+func (yc *YouTubeClient) DeleteCaption(captionID string) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	if err := service.Captions.Delete(captionID).Do(); err != nil {
+		return fmt.Errorf("error deleting caption: %v", err)
+	}
+
+	return nil
+}
+
+// ListCaptions lists the caption tracks of a video. The API only allows this
+// with the youtube.force-ssl scope, i.e. when write access is enabled.
+func (yc *YouTubeClient) ListCaptions(videoID string) ([]*youtube.Caption, error) {
+	service, err := yc.userService()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := service.Captions.List([]string{"snippet"}, videoID).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing captions: %v", err)
+	}
+
+	return response.Items, nil
+}
+
+// DownloadCaption downloads a caption track in the given format (srt or vtt).
+// YouTube only allows this for videos the authenticated user can edit.
+func (yc *YouTubeClient) DownloadCaption(captionID, format string) ([]byte, error) {
+	service, err := yc.userService()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := service.Captions.Download(captionID).Tfmt(format).Download()
+	if err != nil {
+		return nil, fmt.Errorf("error downloading caption: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading caption: %v", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// ListChannelSections lists the sections of a channel page, or of the
+// authenticated user's channel when mine is set
+func (yc *YouTubeClient) ListChannelSections(channelID string, mine bool) ([]*youtube.ChannelSection, error) {
+	service, err := yc.serviceFor(mine)
+	if err != nil {
+		return nil, err
+	}
+
+	call := service.ChannelSections.List([]string{"snippet", "contentDetails"})
+	if mine {
+		call = call.Mine(true)
+	} else {
+		call = call.ChannelId(channelID)
+	}
+
+	response, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing channel sections: %v", err)
+	}
+
+	return response.Items, nil
+}
+
+// GetPlaylists gets several playlists by ID, batching IDs 50 per request
+func (yc *YouTubeClient) GetPlaylists(playlistIDs []string) ([]*youtube.Playlist, error) {
+	var playlists []*youtube.Playlist
+	for start := 0; start < len(playlistIDs); start += maxIDsPerRequest {
+		end := min(start+maxIDsPerRequest, len(playlistIDs))
+
+		response, err := yc.service.Playlists.List([]string{"snippet", "contentDetails"}).
+			Id(playlistIDs[start:end]...).
+			MaxResults(maxIDsPerRequest).
+			Do()
+		if err != nil {
+			return nil, fmt.Errorf("error getting playlists: %v", err)
+		}
+		playlists = append(playlists, response.Items...)
+	}
+
+	return playlists, nil
+}
+
+// GetChannels gets several channels by ID, batching IDs 50 per request
+func (yc *YouTubeClient) GetChannels(channelIDs []string) ([]*youtube.Channel, error) {
+	var channels []*youtube.Channel
+	for start := 0; start < len(channelIDs); start += maxIDsPerRequest {
+		end := min(start+maxIDsPerRequest, len(channelIDs))
+
+		response, err := yc.service.Channels.List([]string{"snippet"}).
+			Id(channelIDs[start:end]...).
+			MaxResults(maxIDsPerRequest).
+			Do()
+		if err != nil {
+			return nil, fmt.Errorf("error getting channels: %v", err)
+		}
+		channels = append(channels, response.Items...)
+	}
+
+	return channels, nil
+}
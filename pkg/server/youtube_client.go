@@ -10,48 +10,110 @@ import (
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
 
 // YouTubeClient wraps the YouTube Data API client
 type YouTubeClient struct {
+	// service serves public data, using the API keys if configured and OAuth2 otherwise
 	service *youtube.Service
-	config  *Config
+	// authService acts on behalf of the authenticated user, nil without OAuth2
+	authService *youtube.Service
+	// authClient sends raw requests as the authenticated user, such as resumable uploads
+	authClient *http.Client
+	config     *Config
+	authMode   string
+	health     *healthTransport
+	authHealth *healthTransport
+	keys       *keyPool
+	reference  referenceCache
 }
 
 // NewYouTubeClient creates a new YouTube client
 func NewYouTubeClient(cfg *Config) (*YouTubeClient, error) {
 	ctx := context.Background()
-	
-	var service *youtube.Service
-	var err error
-	
-	// Try to create service with API key first (for public data)
-	if cfg.YouTubeAPIKey != "" {
-		service, err = youtube.NewService(ctx, option.WithAPIKey(cfg.YouTubeAPIKey))
+	yc := &YouTubeClient{config: cfg}
+
+	// Use the API keys if there are any (for public data)
+	if apiKeys := cfg.APIKeys(); len(apiKeys) > 0 {
+		keys, err := newKeyPool(apiKeys, cfg.APIKeyStrategy, http.DefaultTransport)
+		if err != nil {
+			return nil, err
+		}
+		yc.keys = keys
+		yc.health = &healthTransport{base: keys}
+		yc.authMode = "api_key"
+
+		yc.service, err = newService(ctx, cfg, yc.health)
 		if err != nil {
-			log.Printf("Failed to create service with API key: %v", err)
+			return nil, fmt.Errorf("failed to create YouTube service: %v", err)
 		}
 	}
-	
-	// If API key fails or doesn't exist, try OAuth2
-	if service == nil {
+
+	// Use OAuth2 if there is no API key, or alongside it when writes or
+	// memberships are enabled or a token has already been authorized
+	needsUser := !cfg.ReadOnly || cfg.EnableMemberships || fileExists(cfg.TokenFile)
+	if yc.service == nil || (fileExists(cfg.OAuth2CredentialsFile) && needsUser) {
 		client, err := getOAuth2Client(cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get OAuth2 client: %v", err)
 		}
-		
-		service, err = youtube.NewService(ctx, option.WithHTTPClient(client))
+		yc.authHealth = &healthTransport{base: client.Transport}
+		yc.authClient = &http.Client{Transport: yc.authHealth}
+
+		yc.authService, err = newService(ctx, cfg, yc.authHealth)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create YouTube service: %v", err)
 		}
+
+		if yc.service == nil {
+			yc.service = yc.authService
+			yc.health = yc.authHealth
+			yc.authMode = "oauth2"
+		} else {
+			yc.authMode = "api_key+oauth2"
+		}
+	}
+
+	return yc, nil
+}
+
+// newService creates a YouTube service sending its requests through transport
+func newService(ctx context.Context, cfg *Config, transport http.RoundTripper) (*youtube.Service, error) {
+	opts := []option.ClientOption{option.WithHTTPClient(&http.Client{Transport: transport})}
+	if cfg.APIEndpoint != "" {
+		opts = append(opts, option.WithEndpoint(cfg.APIEndpoint))
+	}
+	return youtube.NewService(ctx, opts...)
+}
+
+// userService returns the OAuth2 service for requests about the authenticated user
+func (yc *YouTubeClient) userService() (*youtube.Service, error) {
+	if yc.authService == nil {
+		return nil, fmt.Errorf("this operation requires OAuth2 credentials (%s)", yc.config.OAuth2CredentialsFile)
+	}
+	return yc.authService, nil
+}
+
+// serviceFor returns the OAuth2 service for requests about the authenticated
+// user (mine), and the public data service otherwise
+func (yc *YouTubeClient) serviceFor(mine bool) (*youtube.Service, error) {
+	if mine {
+		return yc.userService()
+	}
+	return yc.service, nil
+}
+
+// writeService returns the OAuth2 service for requests that modify data.
+// Writes require the server to run with read_only disabled, so that the
+// OAuth2 token was granted the youtube.force-ssl scope.
+func (yc *YouTubeClient) writeService() (*youtube.Service, error) {
+	if yc.config.ReadOnly {
+		return nil, fmt.Errorf("the server is running read-only; set read_only to false and re-authorize to grant the %s scope", youtube.YoutubeForceSslScope)
 	}
-	
-	return &YouTubeClient{
-		service: service,
-		config:  cfg,
-	}, nil
+	return yc.userService()
 }
 
 // getOAuth2Client gets an OAuth2 client for authenticated requests
@@ -61,12 +123,12 @@ func getOAuth2Client(cfg *Config) (*http.Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to read client secret file: %v", err)
 	}
-	
-	oauthConfig, err := google.ConfigFromJSON(b, youtube.YoutubeReadonlyScope)
+
+	oauthConfig, err := google.ConfigFromJSON(b, cfg.OAuthScopes()...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse client secret file: %v", err)
 	}
-	
+
 	return getClient(oauthConfig, cfg.TokenFile), nil
 }
 
@@ -127,55 +189,68 @@ func (yc *YouTubeClient) SearchVideos(query string, maxResults int64, channelID
 		Type("video").
 		MaxResults(maxResults).
 		Order("relevance")
-	
+
 	if channelID != "" {
 		call = call.ChannelId(channelID)
 	}
-	
+
 	response, err := call.Do()
 	if err != nil {
 		return nil, fmt.Errorf("error searching videos: %v", err)
 	}
-	
+
 	return response.Items, nil
 }
 
-// GetChannelInfo gets information about a channel
-func (yc *YouTubeClient) GetChannelInfo(channelID string) (*youtube.Channel, error) {
-	call := yc.service.Channels.List([]string{"snippet", "statistics", "contentDetails"})
-	
+// GetChannelInfo gets information about a channel, requesting any extra parts
+// on top of snippet, statistics and contentDetails
+func (yc *YouTubeClient) GetChannelInfo(channelID string, extraParts []string) (*youtube.Channel, error) {
+	service, err := yc.serviceFor(channelID == "")
+	if err != nil {
+		return nil, err
+	}
+
+	parts := append([]string{"snippet", "statistics", "contentDetails"}, extraParts...)
+	call := service.Channels.List(parts)
+
 	if channelID != "" {
 		call = call.Id(channelID)
 	} else {
 		call = call.Mine(true)
 	}
-	
+
 	response, err := call.Do()
 	if err != nil {
 		return nil, fmt.Errorf("error getting channel info: %v", err)
 	}
-	
+
 	if len(response.Items) == 0 {
 		return nil, fmt.Errorf("channel not found")
 	}
-	
+
 	return response.Items[0], nil
 }
 
-// GetVideoDetails gets detailed information about a video
-func (yc *YouTubeClient) GetVideoDetails(videoID string) (*youtube.Video, error) {
-	call := yc.service.Videos.List([]string{"snippet", "statistics", "contentDetails"}).
+// GetVideoDetails gets detailed information about a video, requesting any
+// extra parts on top of snippet, statistics and contentDetails. A non-empty
+// fields selector limits the response to those resource fields.
+func (yc *YouTubeClient) GetVideoDetails(videoID string, extraParts []string, fields googleapi.Field) (*youtube.Video, error) {
+	parts := append([]string{"snippet", "statistics", "contentDetails"}, extraParts...)
+	call := yc.service.Videos.List(parts).
 		Id(videoID)
-	
+	if fields != "" {
+		call = call.Fields(fields)
+	}
+
 	response, err := call.Do()
 	if err != nil {
 		return nil, fmt.Errorf("error getting video details: %v", err)
 	}
-	
+
 	if len(response.Items) == 0 {
 		return nil, fmt.Errorf("video not found")
 	}
-	
+
 	return response.Items[0], nil
 }
 
@@ -184,12 +259,12 @@ func (yc *YouTubeClient) GetPlaylistItems(playlistID string, maxResults int64) (
 	call := yc.service.PlaylistItems.List([]string{"snippet", "contentDetails"}).
 		PlaylistId(playlistID).
 		MaxResults(maxResults)
-	
+
 	response, err := call.Do()
 	if err != nil {
 		return nil, fmt.Errorf("error getting playlist items: %v", err)
 	}
-	
+
 	return response.Items, nil
 }
 
@@ -200,11 +275,11 @@ func (yc *YouTubeClient) SearchChannels(query string, maxResults int64) ([]*yout
 		Type("channel").
 		MaxResults(maxResults).
 		Order("relevance")
-	
+
 	response, err := call.Do()
 	if err != nil {
 		return nil, fmt.Errorf("error searching channels: %v", err)
 	}
-	
+
 	return response.Items, nil
 }
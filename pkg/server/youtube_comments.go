@@ -0,0 +1,125 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// PostComment posts a new top-level comment on a video
+func (yc *YouTubeClient) PostComment(videoID, text string) (*youtube.CommentThread, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	thread := &youtube.CommentThread{
+		Snippet: &youtube.CommentThreadSnippet{
+			VideoId: videoID,
+			TopLevelComment: &youtube.Comment{
+				Snippet: &youtube.CommentSnippet{
+					TextOriginal: text,
+				},
+			},
+		},
+	}
+
+	created, err := service.CommentThreads.Insert([]string{"snippet"}, thread).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error posting comment: %v", err)
+	}
+
+	return created, nil
+}
+
+// ReplyToComment posts a reply to a top-level comment
+func (yc *YouTubeClient) ReplyToComment(parentID, text string) (*youtube.Comment, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &youtube.Comment{
+		Snippet: &youtube.CommentSnippet{
+			ParentId:     parentID,
+			TextOriginal: text,
+		},
+	}
+
+	created, err := service.Comments.Insert([]string{"snippet"}, comment).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error replying to comment: %v", err)
+	}
+
+	return created, nil
+}
+
+// UpdateComment replaces the text of a comment
+func (yc *YouTubeClient) UpdateComment(commentID, text string) (*youtube.Comment, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &youtube.Comment{
+		Id: commentID,
+		Snippet: &youtube.CommentSnippet{
+			TextOriginal: text,
+		},
+	}
+
+	updated, err := service.Comments.Update([]string{"snippet"}, comment).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error updating comment: %v", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteComment deletes a comment
+func (yc *YouTubeClient) DeleteComment(commentID string) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	if err := service.Comments.Delete(commentID).Do(); err != nil {
+		return fmt.Errorf("error deleting comment: %v", err)
+	}
+
+	return nil
+}
+
+// SetCommentModerationStatus sets the moderation status of comments; banAuthor
+// also bans the authors from the channel and is only allowed when rejecting
+func (yc *YouTubeClient) SetCommentModerationStatus(commentIDs []string, status string, banAuthor bool) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	call := service.Comments.SetModerationStatus(commentIDs, status)
+	if banAuthor {
+		call = call.BanAuthor(true)
+	}
+
+	if err := call.Do(); err != nil {
+		return fmt.Errorf("error setting comment moderation status: %v", err)
+	}
+
+	return nil
+}
+
+// MarkCommentAsSpam flags comments as spam
+func (yc *YouTubeClient) MarkCommentAsSpam(commentIDs []string) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	if err := service.Comments.MarkAsSpam(commentIDs).Do(); err != nil {
+		return fmt.Errorf("error marking comment as spam: %v", err)
+	}
+
+	return nil
+}
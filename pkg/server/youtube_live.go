@@ -0,0 +1,249 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// ListLiveBroadcasts lists the authenticated user's live broadcasts, either by
+// ID, by status (all, upcoming, active or completed) or all of them
+func (yc *YouTubeClient) ListLiveBroadcasts(broadcastIDs []string, status string, maxResults int64, pageToken string) (*youtube.LiveBroadcastListResponse, error) {
+	service, err := yc.userService()
+	if err != nil {
+		return nil, err
+	}
+
+	call := service.LiveBroadcasts.List([]string{"snippet", "status", "contentDetails"})
+	switch {
+	case len(broadcastIDs) > 0:
+		call = call.Id(broadcastIDs...)
+	case status != "":
+		call = call.BroadcastStatus(status)
+	default:
+		call = call.Mine(true)
+	}
+	if len(broadcastIDs) == 0 {
+		call = call.MaxResults(maxResults)
+	}
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+
+	response, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing live broadcasts: %v", err)
+	}
+
+	return response, nil
+}
+
+// ListLiveStreams lists the authenticated user's live streams, or the streams with the given IDs
+func (yc *YouTubeClient) ListLiveStreams(streamIDs []string, maxResults int64, pageToken string) (*youtube.LiveStreamListResponse, error) {
+	service, err := yc.userService()
+	if err != nil {
+		return nil, err
+	}
+
+	call := service.LiveStreams.List([]string{"snippet", "cdn", "status", "contentDetails"})
+	if len(streamIDs) > 0 {
+		call = call.Id(streamIDs...)
+	} else {
+		call = call.Mine(true).MaxResults(maxResults)
+	}
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+
+	response, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing live streams: %v", err)
+	}
+
+	return response, nil
+}
+
+// LiveBroadcastSettings describes a broadcast to schedule
+type LiveBroadcastSettings struct {
+	Title              string
+	Description        string
+	ScheduledStartTime string
+	ScheduledEndTime   string
+	PrivacyStatus      string
+	MadeForKids        bool
+	EnableAutoStart    bool
+	EnableAutoStop     bool
+	EnableDvr          bool
+	EnableMonitor      bool
+	LatencyPreference  string
+}
+
+// BroadcastUpdate holds the broadcast fields to change; nil fields are left as they are
+type BroadcastUpdate struct {
+	Title              *string
+	Description        *string
+	ScheduledStartTime *string
+	ScheduledEndTime   *string
+	PrivacyStatus      *string
+	EnableAutoStart    *bool
+	EnableAutoStop     *bool
+	EnableDvr          *bool
+}
+
+// GetLiveBroadcast gets one of the authenticated user's broadcasts
+func (yc *YouTubeClient) GetLiveBroadcast(broadcastID string) (*youtube.LiveBroadcast, error) {
+	response, err := yc.ListLiveBroadcasts([]string{broadcastID}, "", 0, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Items) == 0 {
+		return nil, fmt.Errorf("live broadcast not found")
+	}
+
+	return response.Items[0], nil
+}
+
+// GetLiveStream gets one of the authenticated user's streams
+func (yc *YouTubeClient) GetLiveStream(streamID string) (*youtube.LiveStream, error) {
+	response, err := yc.ListLiveStreams([]string{streamID}, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Items) == 0 {
+		return nil, fmt.Errorf("live stream not found")
+	}
+
+	return response.Items[0], nil
+}
+
+// CreateLiveBroadcast schedules a broadcast on the authenticated user's channel
+func (yc *YouTubeClient) CreateLiveBroadcast(settings LiveBroadcastSettings) (*youtube.LiveBroadcast, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	broadcast := &youtube.LiveBroadcast{
+		Snippet: &youtube.LiveBroadcastSnippet{
+			Title:              settings.Title,
+			Description:        settings.Description,
+			ScheduledStartTime: settings.ScheduledStartTime,
+			ScheduledEndTime:   settings.ScheduledEndTime,
+		},
+		Status: &youtube.LiveBroadcastStatus{
+			PrivacyStatus:           settings.PrivacyStatus,
+			SelfDeclaredMadeForKids: settings.MadeForKids,
+			ForceSendFields:         []string{"SelfDeclaredMadeForKids"},
+		},
+		ContentDetails: &youtube.LiveBroadcastContentDetails{
+			EnableAutoStart:   settings.EnableAutoStart,
+			EnableAutoStop:    settings.EnableAutoStop,
+			EnableDvr:         settings.EnableDvr,
+			LatencyPreference: settings.LatencyPreference,
+			MonitorStream: &youtube.MonitorStreamInfo{
+				EnableMonitorStream: &settings.EnableMonitor,
+			},
+			ForceSendFields: []string{"EnableAutoStart", "EnableAutoStop", "EnableDvr"},
+		},
+	}
+
+	created, err := service.LiveBroadcasts.Insert([]string{"snippet", "status", "contentDetails"}, broadcast).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error creating live broadcast: %v", err)
+	}
+
+	return created, nil
+}
+
+// BindBroadcastStream binds a stream to a broadcast, or unbinds the current
+// stream when streamID is empty
+func (yc *YouTubeClient) BindBroadcastStream(broadcastID, streamID string) (*youtube.LiveBroadcast, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	call := service.LiveBroadcasts.Bind(broadcastID, []string{"snippet", "status", "contentDetails"})
+	if streamID != "" {
+		call = call.StreamId(streamID)
+	}
+
+	bound, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error binding live stream: %v", err)
+	}
+
+	return bound, nil
+}
+
+// TransitionBroadcast moves a broadcast to the testing, live or complete state
+func (yc *YouTubeClient) TransitionBroadcast(broadcastID, status string) (*youtube.LiveBroadcast, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	transitioned, err := service.LiveBroadcasts.Transition(status, broadcastID, []string{"snippet", "status", "contentDetails"}).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error transitioning live broadcast: %v", err)
+	}
+
+	return transitioned, nil
+}
+
+// UpdateBroadcast changes the title, schedule, privacy or settings of a broadcast
+func (yc *YouTubeClient) UpdateBroadcast(broadcastID string, update BroadcastUpdate) (*youtube.LiveBroadcast, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	// liveBroadcasts.update clears every field of an updated part that is not
+	// sent, so start from the current broadcast
+	broadcast, err := yc.GetLiveBroadcast(broadcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	snippet, status, details := broadcast.Snippet, broadcast.Status, broadcast.ContentDetails
+	if update.Title != nil {
+		snippet.Title = *update.Title
+	}
+	if update.Description != nil {
+		snippet.Description = *update.Description
+		snippet.ForceSendFields = append(snippet.ForceSendFields, "Description")
+	}
+	if update.ScheduledStartTime != nil {
+		snippet.ScheduledStartTime = *update.ScheduledStartTime
+	}
+	if update.ScheduledEndTime != nil {
+		snippet.ScheduledEndTime = *update.ScheduledEndTime
+	}
+	if update.PrivacyStatus != nil {
+		status.PrivacyStatus = *update.PrivacyStatus
+	}
+	if update.EnableAutoStart != nil {
+		details.EnableAutoStart = *update.EnableAutoStart
+		details.ForceSendFields = append(details.ForceSendFields, "EnableAutoStart")
+	}
+	if update.EnableAutoStop != nil {
+		details.EnableAutoStop = *update.EnableAutoStop
+		details.ForceSendFields = append(details.ForceSendFields, "EnableAutoStop")
+	}
+	if update.EnableDvr != nil {
+		details.EnableDvr = *update.EnableDvr
+		details.ForceSendFields = append(details.ForceSendFields, "EnableDvr")
+	}
+
+	updated, err := service.LiveBroadcasts.Update([]string{"snippet", "status", "contentDetails"}, &youtube.LiveBroadcast{
+		Id:             broadcastID,
+		Snippet:        snippet,
+		Status:         status,
+		ContentDetails: details,
+	}).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error updating live broadcast: %v", err)
+	}
+
+	return updated, nil
+}
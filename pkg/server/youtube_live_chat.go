@@ -0,0 +1,227 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// ActiveLiveChatID resolves the live chat of a video that is live or upcoming
+func (yc *YouTubeClient) ActiveLiveChatID(videoID string) (string, error) {
+	video, err := yc.GetVideoDetails(videoID, []string{"liveStreamingDetails"}, "")
+	if err != nil {
+		return "", err
+	}
+	if video.LiveStreamingDetails == nil || video.LiveStreamingDetails.ActiveLiveChatId == "" {
+		return "", fmt.Errorf("video %s has no active live chat", videoID)
+	}
+
+	return video.LiveStreamingDetails.ActiveLiveChatId, nil
+}
+
+// LiveChatPages polls a live chat, yielding one page of messages per request.
+// Between requests it waits the pollingIntervalMillis the API asks for.
+// Iteration ends when the caller stops, the chat goes offline or ctx is
+// cancelled, in which case ctx's error is yielded.
+func (yc *YouTubeClient) LiveChatPages(ctx context.Context, liveChatID, pageToken string, maxResults int64) iter.Seq2[*youtube.LiveChatMessageListResponse, error] {
+	return func(yield func(*youtube.LiveChatMessageListResponse, error) bool) {
+		var wait time.Duration
+		for {
+			if wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					yield(nil, ctx.Err())
+					return
+				case <-timer.C:
+				}
+			}
+
+			call := yc.service.LiveChatMessages.List(liveChatID, []string{"snippet", "authorDetails"}).
+				MaxResults(maxResults).
+				Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+
+			response, err := call.Do()
+			if err != nil {
+				yield(nil, fmt.Errorf("error listing live chat messages: %v", err))
+				return
+			}
+			if !yield(response, nil) || response.OfflineAt != "" || response.NextPageToken == "" {
+				return
+			}
+
+			pageToken = response.NextPageToken
+			wait = time.Duration(response.PollingIntervalMillis) * time.Millisecond
+		}
+	}
+}
+
+// PostLiveChatMessage posts a text message to a live chat as the authenticated user
+func (yc *YouTubeClient) PostLiveChatMessage(liveChatID, text string) (*youtube.LiveChatMessage, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	message := &youtube.LiveChatMessage{
+		Snippet: &youtube.LiveChatMessageSnippet{
+			LiveChatId: liveChatID,
+			Type:       "textMessageEvent",
+			TextMessageDetails: &youtube.LiveChatTextMessageDetails{
+				MessageText: text,
+			},
+		},
+	}
+
+	posted, err := service.LiveChatMessages.Insert([]string{"snippet"}, message).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error posting live chat message: %v", err)
+	}
+
+	return posted, nil
+}
+
+// DeleteLiveChatMessage deletes a live chat message; the user must own or moderate the chat
+func (yc *YouTubeClient) DeleteLiveChatMessage(messageID string) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	if err := service.LiveChatMessages.Delete(messageID).Do(); err != nil {
+		return fmt.Errorf("error deleting live chat message: %v", err)
+	}
+
+	return nil
+}
+
+// BanChatUser bans a channel from a live chat, for durationSeconds or
+// permanently when durationSeconds is 0
+func (yc *YouTubeClient) BanChatUser(liveChatID, channelID string, durationSeconds uint64) (*youtube.LiveChatBan, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	ban := &youtube.LiveChatBan{
+		Snippet: &youtube.LiveChatBanSnippet{
+			LiveChatId: liveChatID,
+			Type:       "permanent",
+			BannedUserDetails: &youtube.ChannelProfileDetails{
+				ChannelId: channelID,
+			},
+		},
+	}
+	if durationSeconds > 0 {
+		ban.Snippet.Type = "temporary"
+		ban.Snippet.BanDurationSeconds = durationSeconds
+	}
+
+	created, err := service.LiveChatBans.Insert([]string{"snippet"}, ban).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error banning chat user: %v", err)
+	}
+
+	return created, nil
+}
+
+// UnbanChatUser lifts a live chat ban
+func (yc *YouTubeClient) UnbanChatUser(banID string) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	if err := service.LiveChatBans.Delete(banID).Do(); err != nil {
+		return fmt.Errorf("error unbanning chat user: %v", err)
+	}
+
+	return nil
+}
+
+// AddChatModerator makes a channel a moderator of a live chat
+func (yc *YouTubeClient) AddChatModerator(liveChatID, channelID string) (*youtube.LiveChatModerator, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	moderator := &youtube.LiveChatModerator{
+		Snippet: &youtube.LiveChatModeratorSnippet{
+			LiveChatId: liveChatID,
+			ModeratorDetails: &youtube.ChannelProfileDetails{
+				ChannelId: channelID,
+			},
+		},
+	}
+
+	created, err := service.LiveChatModerators.Insert([]string{"snippet"}, moderator).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error adding chat moderator: %v", err)
+	}
+
+	return created, nil
+}
+
+// RemoveChatModerator removes a moderator from a live chat
+func (yc *YouTubeClient) RemoveChatModerator(moderatorID string) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	if err := service.LiveChatModerators.Delete(moderatorID).Do(); err != nil {
+		return fmt.Errorf("error removing chat moderator: %v", err)
+	}
+
+	return nil
+}
+
+// ListChatModerators lists the moderators of a live chat owned by the authenticated user
+func (yc *YouTubeClient) ListChatModerators(liveChatID string, maxResults int64, pageToken string) (*youtube.LiveChatModeratorListResponse, error) {
+	service, err := yc.userService()
+	if err != nil {
+		return nil, err
+	}
+
+	call := service.LiveChatModerators.List(liveChatID, []string{"snippet"}).
+		MaxResults(maxResults)
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+
+	response, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing chat moderators: %v", err)
+	}
+
+	return response, nil
+}
+
+// FindChatModeratorID looks up the moderator entry of a channel in a live chat
+func (yc *YouTubeClient) FindChatModeratorID(liveChatID, channelID string) (string, error) {
+	pageToken := ""
+	for {
+		response, err := yc.ListChatModerators(liveChatID, 50, pageToken)
+		if err != nil {
+			return "", err
+		}
+		for _, moderator := range response.Items {
+			if moderator.Snippet != nil && moderator.Snippet.ModeratorDetails != nil &&
+				moderator.Snippet.ModeratorDetails.ChannelId == channelID {
+				return moderator.Id, nil
+			}
+		}
+		if response.NextPageToken == "" {
+			return "", fmt.Errorf("channel %s is not a moderator of this chat", channelID)
+		}
+		pageToken = response.NextPageToken
+	}
+}
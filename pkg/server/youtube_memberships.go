@@ -0,0 +1,55 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// ListMembers lists the members of the authenticated user's channel. Mode is
+// all_current (every current member, newest first) or updates (only members
+// who joined since the previous updates call).
+func (yc *YouTubeClient) ListMembers(mode, levelID string, memberChannelIDs []string, maxResults int64, pageToken string) (*youtube.MemberListResponse, error) {
+	service, err := yc.userService()
+	if err != nil {
+		return nil, err
+	}
+
+	call := service.Members.List([]string{"snippet"}).
+		MaxResults(maxResults)
+	if mode != "" {
+		call = call.Mode(mode)
+	}
+	if levelID != "" {
+		call = call.HasAccessToLevel(levelID)
+	}
+	if len(memberChannelIDs) > 0 {
+		call = call.FilterByMemberChannelId(strings.Join(memberChannelIDs, ","))
+	}
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+
+	response, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing members: %v", err)
+	}
+
+	return response, nil
+}
+
+// ListMembershipLevels lists the membership levels offered by the authenticated user's channel
+func (yc *YouTubeClient) ListMembershipLevels() ([]*youtube.MembershipsLevel, error) {
+	service, err := yc.userService()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := service.MembershipsLevels.List([]string{"id", "snippet"}).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing membership levels: %v", err)
+	}
+
+	return response.Items, nil
+}
@@ -0,0 +1,246 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// PlaylistUpdate holds the playlist fields to change; nil fields are left as they are
+type PlaylistUpdate struct {
+	Title         *string
+	Description   *string
+	PrivacyStatus *string
+}
+
+// CreatePlaylist creates a playlist on the authenticated user's channel
+func (yc *YouTubeClient) CreatePlaylist(title, description, privacyStatus string, tags []string) (*youtube.Playlist, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	playlist := &youtube.Playlist{
+		Snippet: &youtube.PlaylistSnippet{
+			Title:       title,
+			Description: description,
+			Tags:        tags,
+		},
+		Status: &youtube.PlaylistStatus{
+			PrivacyStatus: privacyStatus,
+		},
+	}
+
+	created, err := service.Playlists.Insert([]string{"snippet", "status"}, playlist).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error creating playlist: %v", err)
+	}
+
+	return created, nil
+}
+
+// UpdatePlaylist changes the title, description or privacy of a playlist
+func (yc *YouTubeClient) UpdatePlaylist(playlistID string, update PlaylistUpdate) (*youtube.Playlist, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	// playlists.update replaces the whole snippet, so start from the current values
+	response, err := service.Playlists.List([]string{"snippet", "status"}).Id(playlistID).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error getting playlist: %v", err)
+	}
+	if len(response.Items) == 0 {
+		return nil, fmt.Errorf("playlist not found")
+	}
+	current := response.Items[0]
+
+	playlist := &youtube.Playlist{
+		Id: playlistID,
+		Snippet: &youtube.PlaylistSnippet{
+			Title:           current.Snippet.Title,
+			Description:     current.Snippet.Description,
+			Tags:            current.Snippet.Tags,
+			DefaultLanguage: current.Snippet.DefaultLanguage,
+		},
+		Status: &youtube.PlaylistStatus{
+			PrivacyStatus: current.Status.PrivacyStatus,
+		},
+	}
+	if update.Title != nil {
+		playlist.Snippet.Title = *update.Title
+	}
+	if update.Description != nil {
+		playlist.Snippet.Description = *update.Description
+		playlist.Snippet.ForceSendFields = []string{"Description"}
+	}
+	if update.PrivacyStatus != nil {
+		playlist.Status.PrivacyStatus = *update.PrivacyStatus
+	}
+
+	updated, err := service.Playlists.Update([]string{"snippet", "status"}, playlist).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error updating playlist: %v", err)
+	}
+
+	return updated, nil
+}
+
+// DeletePlaylist deletes a playlist
+func (yc *YouTubeClient) DeletePlaylist(playlistID string) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	if err := service.Playlists.Delete(playlistID).Do(); err != nil {
+		return fmt.Errorf("error deleting playlist: %v", err)
+	}
+
+	return nil
+}
+
+// AddPlaylistItem adds a video to a playlist, at the end unless position is given
+func (yc *YouTubeClient) AddPlaylistItem(playlistID, videoID string, position *int64) (*youtube.PlaylistItem, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	item := &youtube.PlaylistItem{
+		Snippet: &youtube.PlaylistItemSnippet{
+			PlaylistId: playlistID,
+			ResourceId: &youtube.ResourceId{
+				Kind:    "youtube#video",
+				VideoId: videoID,
+			},
+		},
+	}
+	if position != nil {
+		item.Snippet.Position = *position
+		item.Snippet.ForceSendFields = []string{"Position"}
+	}
+
+	created, err := service.PlaylistItems.Insert([]string{"snippet", "contentDetails"}, item).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error adding playlist item: %v", err)
+	}
+
+	return created, nil
+}
+
+// RemovePlaylistItem removes an item from its playlist
+func (yc *YouTubeClient) RemovePlaylistItem(playlistItemID string) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	if err := service.PlaylistItems.Delete(playlistItemID).Do(); err != nil {
+		return fmt.Errorf("error removing playlist item: %v", err)
+	}
+
+	return nil
+}
+
+// ReorderPlaylistItem moves a playlist item to a new zero-based position
+func (yc *YouTubeClient) ReorderPlaylistItem(playlistItemID string, position int64) (*youtube.PlaylistItem, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	// playlistItems.update needs the playlist and video the item refers to
+	response, err := service.PlaylistItems.List([]string{"snippet"}).Id(playlistItemID).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error getting playlist item: %v", err)
+	}
+	if len(response.Items) == 0 {
+		return nil, fmt.Errorf("playlist item not found")
+	}
+	current := response.Items[0]
+
+	item := &youtube.PlaylistItem{
+		Id: playlistItemID,
+		Snippet: &youtube.PlaylistItemSnippet{
+			PlaylistId:      current.Snippet.PlaylistId,
+			ResourceId:      current.Snippet.ResourceId,
+			Position:        position,
+			ForceSendFields: []string{"Position"},
+		},
+	}
+
+	updated, err := service.PlaylistItems.Update([]string{"snippet", "contentDetails"}, item).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error reordering playlist item: %v", err)
+	}
+
+	return updated, nil
+}
+
+// ListPlaylists lists the playlists of a channel, or of the authenticated user when mine is set
+func (yc *YouTubeClient) ListPlaylists(channelID string, mine bool, maxResults int64, pageToken string) (*youtube.PlaylistListResponse, error) {
+	service, err := yc.serviceFor(mine)
+	if err != nil {
+		return nil, err
+	}
+
+	call := service.Playlists.List([]string{"snippet", "contentDetails", "status"}).
+		MaxResults(maxResults)
+
+	if mine {
+		call = call.Mine(true)
+	} else {
+		call = call.ChannelId(channelID)
+	}
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+
+	response, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing playlists: %v", err)
+	}
+
+	return response, nil
+}
+
+// GetUploadsPlaylistID resolves the special playlist holding all uploads of a
+// channel, or of the authenticated user's channel when channelID is empty.
+// The ID never changes, so it is cached with the reference data.
+func (yc *YouTubeClient) GetUploadsPlaylistID(channelID string) (string, error) {
+	value, err := yc.reference.get("uploads:"+channelID, func() (interface{}, error) {
+		service, err := yc.serviceFor(channelID == "")
+		if err != nil {
+			return nil, err
+		}
+
+		call := service.Channels.List([]string{"contentDetails"})
+		if channelID != "" {
+			call = call.Id(channelID)
+		} else {
+			call = call.Mine(true)
+		}
+
+		response, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("error getting channel: %v", err)
+		}
+		if len(response.Items) == 0 {
+			return nil, fmt.Errorf("channel not found")
+		}
+
+		details := response.Items[0].ContentDetails
+		if details == nil || details.RelatedPlaylists == nil || details.RelatedPlaylists.Uploads == "" {
+			return nil, fmt.Errorf("channel has no uploads playlist")
+		}
+
+		return details.RelatedPlaylists.Uploads, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return value.(string), nil
+}
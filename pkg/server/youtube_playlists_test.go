@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestGetUploadsPlaylistIDIsCached(t *testing.T) {
+	var lookups []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		channelID := r.URL.Query().Get("id")
+		if r.URL.Query().Get("mine") == "true" {
+			channelID = "UCmine"
+		}
+		lookups = append(lookups, channelID)
+		if channelID == "UCmissing" {
+			json.NewEncoder(w).Encode(&youtube.ChannelListResponse{})
+			return
+		}
+
+		json.NewEncoder(w).Encode(&youtube.ChannelListResponse{Items: []*youtube.Channel{{
+			Id: channelID,
+			ContentDetails: &youtube.ChannelContentDetails{
+				RelatedPlaylists: &youtube.ChannelContentDetailsRelatedPlaylists{Uploads: "UU" + channelID[2:]},
+			},
+		}}})
+	}))
+	defer server.Close()
+
+	cfg := &Config{APIEndpoint: server.URL + "/"}
+	service, err := newService(context.Background(), cfg, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("newService: %v", err)
+	}
+	yc := &YouTubeClient{config: cfg, service: service, authService: service}
+
+	tests := []struct {
+		channelID string
+		want      string
+		wantErr   bool
+	}{
+		{channelID: "UCone", want: "UUone"},
+		{channelID: "UCone", want: "UUone"},
+		{channelID: "UCtwo", want: "UUtwo"},
+		{channelID: "", want: "UUmine"},
+		{channelID: "", want: "UUmine"},
+		{channelID: "UCmissing", wantErr: true},
+		{channelID: "UCmissing", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := yc.GetUploadsPlaylistID(tt.channelID)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("GetUploadsPlaylistID(%q) = %q, %v, want %q", tt.channelID, got, err, tt.want)
+		}
+	}
+
+	// One lookup per channel, failures included
+	if want := []string{"UCone", "UCtwo", "UCmine", "UCmissing"}; len(lookups) != len(want) {
+		t.Errorf("channel lookups = %v, want %v", lookups, want)
+	}
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// RateVideo likes or dislikes a video as the authenticated user, or removes the rating with "none"
+func (yc *YouTubeClient) RateVideo(videoID, rating string) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	if err := service.Videos.Rate(videoID, rating).Do(); err != nil {
+		return fmt.Errorf("error rating video: %v", err)
+	}
+
+	return nil
+}
+
+// GetMyRatings gets the authenticated user's ratings for several videos
+func (yc *YouTubeClient) GetMyRatings(videoIDs []string) ([]*youtube.VideoRating, error) {
+	service, err := yc.userService()
+	if err != nil {
+		return nil, err
+	}
+
+	var ratings []*youtube.VideoRating
+	for start := 0; start < len(videoIDs); start += maxIDsPerRequest {
+		end := min(start+maxIDsPerRequest, len(videoIDs))
+
+		response, err := service.Videos.GetRating(videoIDs[start:end]).Do()
+		if err != nil {
+			return nil, fmt.Errorf("error getting video ratings: %v", err)
+		}
+		ratings = append(ratings, response.Items...)
+	}
+
+	return ratings, nil
+}
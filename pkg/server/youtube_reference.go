@@ -0,0 +1,150 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// referenceCacheTTL is how long reference data such as categories, regions and
+// languages is kept; it changes rarely, so a day saves quota on every lookup
+const referenceCacheTTL = 24 * time.Hour
+
+// referenceErrorTTL is how long a failed reference data lookup is remembered,
+// so that naming the categories of many videos does not repeat a failing call
+const referenceErrorTTL = 5 * time.Minute
+
+// defaultCategoryRegion is the region whose categories are used to name category
+// IDs when the request does not name one
+const defaultCategoryRegion = "US"
+
+// referenceCache holds reference data responses keyed by request
+type referenceCache struct {
+	mu      sync.Mutex
+	entries map[string]referenceEntry
+}
+
+type referenceEntry struct {
+	value   interface{}
+	err     error
+	expires time.Time
+}
+
+// get returns the cached value for key, calling load on a miss or after expiry.
+// Failures are cached too, for referenceErrorTTL.
+func (c *referenceCache) get(key string, load func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.value, entry.err
+	}
+
+	value, err := load()
+	entry = referenceEntry{value: value, err: err, expires: time.Now().Add(referenceCacheTTL)}
+	if err != nil {
+		entry = referenceEntry{err: err, expires: time.Now().Add(referenceErrorTTL)}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]referenceEntry)
+	}
+	c.entries[key] = entry
+	return entry.value, entry.err
+}
+
+// ListVideoCategories lists the video categories available in a region,
+// with titles in the language hl
+func (yc *YouTubeClient) ListVideoCategories(regionCode, hl string) ([]*youtube.VideoCategory, error) {
+	value, err := yc.reference.get("categories:"+regionCode+":"+hl, func() (interface{}, error) {
+		call := yc.service.VideoCategories.List([]string{"snippet"}).RegionCode(regionCode)
+		if hl != "" {
+			call = call.Hl(hl)
+		}
+
+		response, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("error listing video categories: %v", err)
+		}
+		return response.Items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]*youtube.VideoCategory), nil
+}
+
+// ListRegions lists the content regions YouTube supports
+func (yc *YouTubeClient) ListRegions(hl string) ([]*youtube.I18nRegion, error) {
+	value, err := yc.reference.get("regions:"+hl, func() (interface{}, error) {
+		call := yc.service.I18nRegions.List([]string{"snippet"})
+		if hl != "" {
+			call = call.Hl(hl)
+		}
+
+		response, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("error listing regions: %v", err)
+		}
+		return response.Items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]*youtube.I18nRegion), nil
+}
+
+// ListLanguages lists the application languages YouTube supports
+func (yc *YouTubeClient) ListLanguages(hl string) ([]*youtube.I18nLanguage, error) {
+	value, err := yc.reference.get("languages:"+hl, func() (interface{}, error) {
+		call := yc.service.I18nLanguages.List([]string{"snippet"})
+		if hl != "" {
+			call = call.Hl(hl)
+		}
+
+		response, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("error listing languages: %v", err)
+		}
+		return response.Items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]*youtube.I18nLanguage), nil
+}
+
+// CategoryName resolves a video category ID into its name, using the
+// categories of the region the request is about (the default region if
+// empty, or if the region does not list the ID). Lookup failures are not
+// fatal to the caller, so an unknown ID yields an empty name.
+func (yc *YouTubeClient) CategoryName(categoryID, regionCode string) string {
+	if categoryID == "" {
+		return ""
+	}
+
+	regions := []string{defaultCategoryRegion}
+	if regionCode = strings.ToUpper(regionCode); regionCode != "" && regionCode != defaultCategoryRegion {
+		regions = []string{regionCode, defaultCategoryRegion}
+	}
+	for _, region := range regions {
+		categories, err := yc.ListVideoCategories(region, "")
+		if err != nil {
+			continue
+		}
+		for _, category := range categories {
+			if category.Id == categoryID && category.Snippet != nil {
+				return category.Snippet.Title
+			}
+		}
+	}
+	return ""
+}
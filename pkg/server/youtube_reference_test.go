@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestReferenceCache(t *testing.T) {
+	var cache referenceCache
+	loads := 0
+	load := func() (interface{}, error) {
+		loads++
+		return loads, nil
+	}
+
+	for range 3 {
+		value, err := cache.get("key", load)
+		if err != nil || value != 1 {
+			t.Fatalf("get = %v, %v, want the first load", value, err)
+		}
+	}
+
+	// An expired entry is loaded again
+	cache.entries["key"] = referenceEntry{value: 1, expires: time.Now().Add(-time.Second)}
+	if value, _ := cache.get("key", load); value != 2 {
+		t.Errorf("get after expiry = %v, want 2", value)
+	}
+}
+
+func TestReferenceCacheRemembersFailures(t *testing.T) {
+	var cache referenceCache
+	loads := 0
+	failure := errors.New("quota exceeded")
+	load := func() (interface{}, error) {
+		loads++
+		return nil, failure
+	}
+
+	for range 3 {
+		if _, err := cache.get("key", load); !errors.Is(err, failure) {
+			t.Fatalf("get error = %v, want %v", err, failure)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("failing load ran %d times, want 1", loads)
+	}
+
+	entry := cache.entries["key"]
+	if ttl := time.Until(entry.expires); ttl > referenceErrorTTL || ttl < referenceErrorTTL-time.Minute {
+		t.Errorf("failure cached for %v, want about %v", ttl, referenceErrorTTL)
+	}
+}
+
+// fakeCategoriesClient serves videoCategories.list, recording the region of
+// every request, and fails when fail is set
+func fakeCategoriesClient(t *testing.T, fail bool, regions *[]string) *YouTubeClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		region := r.URL.Query().Get("regionCode")
+		*regions = append(*regions, region)
+		if fail {
+			http.Error(w, `{"error":{"code":403,"errors":[{"reason":"quotaExceeded"}]}}`, http.StatusForbidden)
+			return
+		}
+
+		categories := map[string][]*youtube.VideoCategory{
+			"US": {
+				{Id: "10", Snippet: &youtube.VideoCategorySnippet{Title: "Music"}},
+				{Id: "28", Snippet: &youtube.VideoCategorySnippet{Title: "Science & Technology"}},
+			},
+			"DE": {
+				{Id: "10", Snippet: &youtube.VideoCategorySnippet{Title: "Musik"}},
+			},
+		}
+		json.NewEncoder(w).Encode(&youtube.VideoCategoryListResponse{Items: categories[region]})
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &Config{APIEndpoint: server.URL + "/"}
+	service, err := newService(context.Background(), cfg, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("newService: %v", err)
+	}
+	return &YouTubeClient{config: cfg, service: service}
+}
+
+func TestCategoryName(t *testing.T) {
+	var regions []string
+	yc := fakeCategoriesClient(t, false, &regions)
+
+	tests := []struct {
+		id, region, want string
+	}{
+		{"28", "", "Science & Technology"},
+		{"10", "", "Music"},
+		{"10", "de", "Musik"},
+		{"28", "DE", "Science & Technology"}, // not listed in DE, falls back to US
+		{"99", "", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		if got := yc.CategoryName(tt.id, tt.region); got != tt.want {
+			t.Errorf("CategoryName(%q, %q) = %q, want %q", tt.id, tt.region, got, tt.want)
+		}
+	}
+
+	// Each region is only loaded once
+	if len(regions) != 2 || regions[0] != "US" || regions[1] != "DE" {
+		t.Errorf("loaded regions %v, want [US DE]", regions)
+	}
+}
+
+func TestCategoryNameFailingLookupIsNotRepeated(t *testing.T) {
+	var regions []string
+	yc := fakeCategoriesClient(t, true, &regions)
+
+	for range 50 {
+		if got := yc.CategoryName("28", ""); got != "" {
+			t.Fatalf("CategoryName = %q, want empty on failure", got)
+		}
+	}
+	if len(regions) != 1 {
+		t.Errorf("made %d category lookups for 50 videos, want 1", len(regions))
+	}
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// ListSubscriptions lists the subscriptions of a channel, or of the
+// authenticated user when mine is set. order is alphabetical, relevance or unread.
+func (yc *YouTubeClient) ListSubscriptions(channelID string, mine bool, order string, maxResults int64, pageToken string) (*youtube.SubscriptionListResponse, error) {
+	service, err := yc.serviceFor(mine)
+	if err != nil {
+		return nil, err
+	}
+
+	call := service.Subscriptions.List([]string{"snippet", "contentDetails"}).
+		MaxResults(maxResults)
+
+	if mine {
+		call = call.Mine(true)
+	} else {
+		call = call.ChannelId(channelID)
+	}
+	if order != "" {
+		call = call.Order(order)
+	}
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+
+	response, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing subscriptions: %v", err)
+	}
+
+	return response, nil
+}
+
+// Subscribe subscribes the authenticated user to a channel
+func (yc *YouTubeClient) Subscribe(channelID string) (*youtube.Subscription, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &youtube.Subscription{
+		Snippet: &youtube.SubscriptionSnippet{
+			ResourceId: &youtube.ResourceId{
+				Kind:      "youtube#channel",
+				ChannelId: channelID,
+			},
+		},
+	}
+
+	created, err := service.Subscriptions.Insert([]string{"snippet"}, subscription).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error subscribing: %v", err)
+	}
+
+	return created, nil
+}
+
+// FindSubscriptionID looks up the authenticated user's subscription to a channel
+func (yc *YouTubeClient) FindSubscriptionID(channelID string) (string, error) {
+	service, err := yc.userService()
+	if err != nil {
+		return "", err
+	}
+
+	response, err := service.Subscriptions.List([]string{"id"}).
+		Mine(true).
+		ForChannelId(channelID).
+		Do()
+	if err != nil {
+		return "", fmt.Errorf("error looking up subscription: %v", err)
+	}
+	if len(response.Items) == 0 {
+		return "", fmt.Errorf("not subscribed to channel %s", channelID)
+	}
+
+	return response.Items[0].Id, nil
+}
+
+// Unsubscribe deletes a subscription
+func (yc *YouTubeClient) Unsubscribe(subscriptionID string) error {
+	service, err := yc.writeService()
+	if err != nil {
+		return err
+	}
+
+	if err := service.Subscriptions.Delete(subscriptionID).Do(); err != nil {
+		return fmt.Errorf("error unsubscribing: %v", err)
+	}
+
+	return nil
+}
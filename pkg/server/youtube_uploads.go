@@ -0,0 +1,117 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// maxIDsPerRequest is the most IDs the API accepts in a single list call
+const maxIDsPerRequest = 50
+
+// maxChannelUploads caps how many uploads a single listing returns
+const maxChannelUploads = 5000
+
+// maxChannelUploadPages caps how many pages of the uploads playlist a single
+// listing reads, whether or not their uploads fall in the date range, so that
+// a narrow range on a large channel costs at most 100 quota units
+const maxChannelUploadPages = maxChannelUploads / maxIDsPerRequest
+
+// ListChannelUploads walks the uploads playlist of a channel (or of the
+// authenticated user's channel when channelID is empty) at 1 quota unit per
+// page. Uploads published outside the given range are skipped; zero times
+// leave the range open. At most limit items are returned; a limit of zero or
+// less returns all uploads, up to maxChannelUploads. The walk stops after
+// maxChannelUploadPages pages; truncated reports whether uploads were left
+// unread because of either cap.
+func (yc *YouTubeClient) ListChannelUploads(channelID string, publishedAfter, publishedBefore time.Time, limit int) (uploadsID string, uploads []*youtube.PlaylistItem, truncated bool, err error) {
+	if limit <= 0 || limit > maxChannelUploads {
+		limit = maxChannelUploads
+	}
+
+	uploadsID, err = yc.GetUploadsPlaylistID(channelID)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	service, err := yc.serviceFor(channelID == "")
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	pageToken := ""
+	for page := 1; ; page++ {
+		call := service.PlaylistItems.List([]string{"snippet", "contentDetails"}).
+			PlaylistId(uploadsID).
+			MaxResults(maxIDsPerRequest)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		response, err := call.Do()
+		if err != nil {
+			return "", nil, false, fmt.Errorf("error listing uploads: %v", err)
+		}
+
+		// The uploads playlist is ordered newest first, so once a whole page
+		// predates the range there is nothing left to find
+		allOlder := len(response.Items) > 0
+		for i, item := range response.Items {
+			published := uploadPublishedAt(item)
+			if !publishedAfter.IsZero() && published.Before(publishedAfter) {
+				continue
+			}
+			allOlder = false
+			if !publishedBefore.IsZero() && !published.Before(publishedBefore) {
+				continue
+			}
+
+			uploads = append(uploads, item)
+			if len(uploads) >= limit {
+				more := i < len(response.Items)-1 || response.NextPageToken != ""
+				return uploadsID, uploads, limit == maxChannelUploads && more, nil
+			}
+		}
+
+		pageToken = response.NextPageToken
+		if pageToken == "" || (allOlder && !publishedAfter.IsZero()) {
+			return uploadsID, uploads, false, nil
+		}
+		if page == maxChannelUploadPages {
+			return uploadsID, uploads, true, nil
+		}
+	}
+}
+
+// uploadPublishedAt returns when the video of a playlist item was published
+func uploadPublishedAt(item *youtube.PlaylistItem) time.Time {
+	publishedAt := ""
+	if item.ContentDetails != nil {
+		publishedAt = item.ContentDetails.VideoPublishedAt
+	}
+	if publishedAt == "" && item.Snippet != nil {
+		publishedAt = item.Snippet.PublishedAt
+	}
+	t, _ := time.Parse(time.RFC3339, publishedAt)
+	return t
+}
+
+// GetVideos gets the details of several videos, batching IDs 50 per request
+func (yc *YouTubeClient) GetVideos(videoIDs []string, parts []string) ([]*youtube.Video, error) {
+	var videos []*youtube.Video
+	for start := 0; start < len(videoIDs); start += maxIDsPerRequest {
+		end := min(start+maxIDsPerRequest, len(videoIDs))
+
+		response, err := yc.service.Videos.List(parts).
+			Id(videoIDs[start:end]...).
+			MaxResults(maxIDsPerRequest).
+			Do()
+		if err != nil {
+			return nil, fmt.Errorf("error getting videos: %v", err)
+		}
+		videos = append(videos, response.Items...)
+	}
+
+	return videos, nil
+}
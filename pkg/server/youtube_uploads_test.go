@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// uploadsEpoch is when the newest upload of the fake channel was published;
+// each older upload was published an hour before the previous one
+var uploadsEpoch = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// fakeUploadsClient serves a channel with the given number of uploads, newest
+// first, 50 per page, and counts the playlist pages read
+func fakeUploadsClient(t *testing.T, total int, pages *int) *YouTubeClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/channels") {
+			json.NewEncoder(w).Encode(&youtube.ChannelListResponse{Items: []*youtube.Channel{{
+				ContentDetails: &youtube.ChannelContentDetails{
+					RelatedPlaylists: &youtube.ChannelContentDetailsRelatedPlaylists{Uploads: "UUchan"},
+				},
+			}}})
+			return
+		}
+
+		*pages++
+		start, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+		end := min(start+maxIDsPerRequest, total)
+		response := &youtube.PlaylistItemListResponse{}
+		for i := start; i < end; i++ {
+			response.Items = append(response.Items, &youtube.PlaylistItem{
+				Snippet: &youtube.PlaylistItemSnippet{Title: fmt.Sprint("Video ", i)},
+				ContentDetails: &youtube.PlaylistItemContentDetails{
+					VideoId:          fmt.Sprint("v", i),
+					VideoPublishedAt: uploadsEpoch.Add(-time.Duration(i) * time.Hour).Format(time.RFC3339),
+				},
+			})
+		}
+		if end < total {
+			response.NextPageToken = strconv.Itoa(end)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &Config{APIEndpoint: server.URL + "/"}
+	service, err := newService(context.Background(), cfg, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("newService: %v", err)
+	}
+	return &YouTubeClient{config: cfg, service: service}
+}
+
+func TestListChannelUploads(t *testing.T) {
+	hoursAgo := func(n int) time.Time { return uploadsEpoch.Add(-time.Duration(n) * time.Hour) }
+
+	tests := []struct {
+		name          string
+		total         int
+		after, before time.Time
+		limit         int
+		wantCount     int
+		wantPages     int
+		wantTruncated bool
+	}{
+		{name: "all uploads", total: 120, wantCount: 120, wantPages: 3},
+		{name: "limit", total: 120, limit: 60, wantCount: 60, wantPages: 2},
+		{name: "limit at the end of the playlist", total: 100, limit: 100, wantCount: 100, wantPages: 2},
+		{name: "published after stops at the first older page", total: 500, after: hoursAgo(74), wantCount: 75, wantPages: 3},
+		{name: "date range", total: 500, after: hoursAgo(120), before: hoursAgo(99), wantCount: 21, wantPages: 4},
+		{name: "all uploads capped", total: 6000, wantCount: maxChannelUploads, wantPages: maxChannelUploadPages, wantTruncated: true},
+		{name: "a narrow old range reads at most the page cap", total: 20000, before: hoursAgo(15000), after: hoursAgo(15010), wantCount: 0, wantPages: maxChannelUploadPages, wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := 0
+			yc := fakeUploadsClient(t, tt.total, &pages)
+
+			uploadsID, uploads, truncated, err := yc.ListChannelUploads("UCchan", tt.after, tt.before, tt.limit)
+			if err != nil {
+				t.Fatalf("ListChannelUploads: %v", err)
+			}
+			if uploadsID != "UUchan" {
+				t.Errorf("uploads playlist = %q, want UUchan", uploadsID)
+			}
+			if len(uploads) != tt.wantCount || pages != tt.wantPages || truncated != tt.wantTruncated {
+				t.Errorf("got %d uploads from %d pages, truncated %v; want %d from %d, truncated %v",
+					len(uploads), pages, truncated, tt.wantCount, tt.wantPages, tt.wantTruncated)
+			}
+		})
+	}
+}
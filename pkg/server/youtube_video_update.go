@@ -0,0 +1,169 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// VideoUpdate holds the video fields to change; nil fields are left as they are
+type VideoUpdate struct {
+	Title               *string
+	Description         *string
+	Tags                *[]string
+	CategoryID          *string
+	DefaultLanguage     *string
+	PrivacyStatus       *string
+	PublishAt           *string
+	MadeForKids         *bool
+	Embeddable          *bool
+	PublicStatsViewable *bool
+	License             *string
+}
+
+// FieldChange is a single field changed by an update
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// apply copies the supplied fields onto the video and returns what changed
+func (u VideoUpdate) apply(video *youtube.Video) []FieldChange {
+	var changes []FieldChange
+	set := func(field string, current interface{}, value interface{}, assign func()) {
+		if reflect.DeepEqual(current, value) {
+			return
+		}
+		changes = append(changes, FieldChange{Field: field, Before: current, After: value})
+		assign()
+	}
+
+	snippet, status := video.Snippet, video.Status
+	if u.Title != nil {
+		set("title", snippet.Title, *u.Title, func() { snippet.Title = *u.Title })
+	}
+	if u.Description != nil {
+		set("description", snippet.Description, *u.Description, func() {
+			snippet.Description = *u.Description
+			snippet.ForceSendFields = append(snippet.ForceSendFields, "Description")
+		})
+	}
+	if u.Tags != nil && len(snippet.Tags)+len(*u.Tags) > 0 {
+		set("tags", snippet.Tags, *u.Tags, func() {
+			snippet.Tags = *u.Tags
+			snippet.ForceSendFields = append(snippet.ForceSendFields, "Tags")
+		})
+	}
+	if u.CategoryID != nil {
+		set("category_id", snippet.CategoryId, *u.CategoryID, func() { snippet.CategoryId = *u.CategoryID })
+	}
+	if u.DefaultLanguage != nil {
+		set("default_language", snippet.DefaultLanguage, *u.DefaultLanguage, func() {
+			snippet.DefaultLanguage = *u.DefaultLanguage
+			if snippet.DefaultLanguage == "" {
+				snippet.ForceSendFields = append(snippet.ForceSendFields, "DefaultLanguage")
+			}
+		})
+	}
+	if u.PrivacyStatus != nil {
+		set("privacy_status", status.PrivacyStatus, *u.PrivacyStatus, func() { status.PrivacyStatus = *u.PrivacyStatus })
+	}
+	if u.PublishAt != nil {
+		// An empty publish_at cancels a scheduled publish
+		set("publish_at", status.PublishAt, *u.PublishAt, func() {
+			status.PublishAt = *u.PublishAt
+			if status.PublishAt == "" {
+				status.ForceSendFields = append(status.ForceSendFields, "PublishAt")
+			}
+		})
+	}
+	if u.MadeForKids != nil {
+		set("made_for_kids", status.SelfDeclaredMadeForKids, *u.MadeForKids, func() {
+			status.SelfDeclaredMadeForKids = *u.MadeForKids
+			status.ForceSendFields = append(status.ForceSendFields, "SelfDeclaredMadeForKids")
+		})
+	}
+	if u.Embeddable != nil {
+		set("embeddable", status.Embeddable, *u.Embeddable, func() {
+			status.Embeddable = *u.Embeddable
+			status.ForceSendFields = append(status.ForceSendFields, "Embeddable")
+		})
+	}
+	if u.PublicStatsViewable != nil {
+		set("public_stats_viewable", status.PublicStatsViewable, *u.PublicStatsViewable, func() {
+			status.PublicStatsViewable = *u.PublicStatsViewable
+			status.ForceSendFields = append(status.ForceSendFields, "PublicStatsViewable")
+		})
+	}
+	if u.License != nil {
+		set("license", status.License, *u.License, func() { status.License = *u.License })
+	}
+
+	return changes
+}
+
+// UpdateVideo reads the current snippet and status of a video, applies only
+// the supplied fields and writes the result back with videos.update. With
+// dryRun the changes are computed but nothing is written.
+func (yc *YouTubeClient) UpdateVideo(videoID string, update VideoUpdate, dryRun bool) (*youtube.Video, []FieldChange, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// videos.update replaces whole parts, so start from the current values to
+	// keep the fields this update does not touch
+	response, err := service.Videos.List([]string{"snippet", "status"}).Id(videoID).Do()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting video: %v", err)
+	}
+	if len(response.Items) == 0 {
+		return nil, nil, fmt.Errorf("video not found")
+	}
+	video := updatableVideo(response.Items[0])
+
+	changes := update.apply(video)
+	if dryRun || len(changes) == 0 {
+		return video, changes, nil
+	}
+
+	updated, err := service.Videos.Update([]string{"snippet", "status"}, video).Do()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error updating video: %v", err)
+	}
+
+	return updated, changes, nil
+}
+
+// updatableVideo copies the snippet and status of a video for videos.update,
+// keeping every writable field and dropping the read-only ones the API sets
+func updatableVideo(current *youtube.Video) *youtube.Video {
+	snippet := youtube.VideoSnippet{}
+	if current.Snippet != nil {
+		snippet = *current.Snippet
+	}
+	snippet.Tags = slices.Clone(snippet.Tags)
+	snippet.ChannelId = ""
+	snippet.ChannelTitle = ""
+	snippet.PublishedAt = ""
+	snippet.Thumbnails = nil
+	snippet.LiveBroadcastContent = ""
+	snippet.Localized = nil
+	snippet.ForceSendFields = nil
+
+	status := youtube.VideoStatus{}
+	if current.Status != nil {
+		status = *current.Status
+	}
+	status.UploadStatus = ""
+	status.FailureReason = ""
+	status.RejectionReason = ""
+	status.MadeForKids = false
+	// Send false values too, so that they are not reset to their defaults
+	status.ForceSendFields = []string{"Embeddable", "PublicStatsViewable", "SelfDeclaredMadeForKids", "ContainsSyntheticMedia"}
+
+	return &youtube.Video{Id: current.Id, Snippet: &snippet, Status: &status}
+}
@@ -0,0 +1,276 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+func stringPtr(s string) *string { return &s }
+func boolPtr(b bool) *bool       { return &b }
+
+// testVideo returns a video as videos.list reports it, read-only fields included
+func testVideo() *youtube.Video {
+	return &youtube.Video{
+		Id: "vid1",
+		Snippet: &youtube.VideoSnippet{
+			Title:                "Old title",
+			Description:          "Old description",
+			Tags:                 []string{"go", "mcp"},
+			CategoryId:           "28",
+			DefaultLanguage:      "en",
+			DefaultAudioLanguage: "en-US",
+			ChannelId:            "UC123",
+			ChannelTitle:         "Channel",
+			PublishedAt:          "2024-01-01T00:00:00Z",
+			LiveBroadcastContent: "none",
+			Thumbnails:           &youtube.ThumbnailDetails{Default: &youtube.Thumbnail{Url: "https://i.ytimg.com/default.jpg"}},
+			Localized:            &youtube.VideoLocalization{Title: "Old title"},
+		},
+		Status: &youtube.VideoStatus{
+			PrivacyStatus:           "private",
+			PublishAt:               "2024-02-01T12:00:00Z",
+			License:                 "youtube",
+			Embeddable:              true,
+			PublicStatsViewable:     false,
+			SelfDeclaredMadeForKids: false,
+			ContainsSyntheticMedia:  true,
+			MadeForKids:             false,
+			UploadStatus:            "processed",
+		},
+	}
+}
+
+func TestVideoUpdateApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  VideoUpdate
+		want    []FieldChange
+		applied func(*youtube.Video) bool
+	}{
+		{
+			name: "no fields changes nothing",
+		},
+		{
+			name:   "same value is not a change",
+			update: VideoUpdate{Title: stringPtr("Old title"), Embeddable: boolPtr(true)},
+		},
+		{
+			name:    "title only",
+			update:  VideoUpdate{Title: stringPtr("New title")},
+			want:    []FieldChange{{Field: "title", Before: "Old title", After: "New title"}},
+			applied: func(v *youtube.Video) bool { return v.Snippet.Title == "New title" },
+		},
+		{
+			name:   "clearing the description",
+			update: VideoUpdate{Description: stringPtr("")},
+			want:   []FieldChange{{Field: "description", Before: "Old description", After: ""}},
+			applied: func(v *youtube.Video) bool {
+				return v.Snippet.Description == "" && slices.Contains(v.Snippet.ForceSendFields, "Description")
+			},
+		},
+		{
+			name:    "replacing tags",
+			update:  VideoUpdate{Tags: &[]string{"go"}},
+			want:    []FieldChange{{Field: "tags", Before: []string{"go", "mcp"}, After: []string{"go"}}},
+			applied: func(v *youtube.Video) bool { return slices.Equal(v.Snippet.Tags, []string{"go"}) },
+		},
+		{
+			name:   "status fields",
+			update: VideoUpdate{PrivacyStatus: stringPtr("public"), PublicStatsViewable: boolPtr(true), License: stringPtr("youtube")},
+			want: []FieldChange{
+				{Field: "privacy_status", Before: "private", After: "public"},
+				{Field: "public_stats_viewable", Before: false, After: true},
+			},
+			applied: func(v *youtube.Video) bool {
+				return v.Status.PrivacyStatus == "public" && v.Status.PublicStatsViewable
+			},
+		},
+		{
+			name:   "clearing the default language",
+			update: VideoUpdate{DefaultLanguage: stringPtr("")},
+			want:   []FieldChange{{Field: "default_language", Before: "en", After: ""}},
+			applied: func(v *youtube.Video) bool {
+				return v.Snippet.DefaultLanguage == "" && slices.Contains(v.Snippet.ForceSendFields, "DefaultLanguage")
+			},
+		},
+		{
+			name:   "cancelling a scheduled publish",
+			update: VideoUpdate{PublishAt: stringPtr("")},
+			want:   []FieldChange{{Field: "publish_at", Before: "2024-02-01T12:00:00Z", After: ""}},
+			applied: func(v *youtube.Video) bool {
+				return v.Status.PublishAt == "" && slices.Contains(v.Status.ForceSendFields, "PublishAt")
+			},
+		},
+		{
+			name:   "rescheduling is not force sent",
+			update: VideoUpdate{PublishAt: stringPtr("2024-03-01T12:00:00Z")},
+			want:   []FieldChange{{Field: "publish_at", Before: "2024-02-01T12:00:00Z", After: "2024-03-01T12:00:00Z"}},
+			applied: func(v *youtube.Video) bool {
+				return v.Status.PublishAt == "2024-03-01T12:00:00Z" && !slices.Contains(v.Status.ForceSendFields, "PublishAt")
+			},
+		},
+		{
+			name:   "made for kids is the self-declared flag",
+			update: VideoUpdate{MadeForKids: boolPtr(true)},
+			want:   []FieldChange{{Field: "made_for_kids", Before: false, After: true}},
+			applied: func(v *youtube.Video) bool {
+				return v.Status.SelfDeclaredMadeForKids && !v.Status.MadeForKids
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			video := updatableVideo(testVideo())
+			changes := tt.update.apply(video)
+			if !reflect.DeepEqual(changes, tt.want) {
+				t.Errorf("changes = %#v, want %#v", changes, tt.want)
+			}
+			if tt.applied != nil && !tt.applied(video) {
+				t.Errorf("update was not applied: snippet %+v, status %+v", video.Snippet, video.Status)
+			}
+		})
+	}
+}
+
+func TestUpdatableVideo(t *testing.T) {
+	current := testVideo()
+	video := updatableVideo(current)
+
+	// Writable fields are kept, including those VideoUpdate cannot set
+	if video.Snippet.DefaultAudioLanguage != "en-US" {
+		t.Errorf("defaultAudioLanguage = %q, want it kept", video.Snippet.DefaultAudioLanguage)
+	}
+	if !video.Status.ContainsSyntheticMedia {
+		t.Error("containsSyntheticMedia was not kept")
+	}
+	if video.Snippet.Title != "Old title" || video.Status.License != "youtube" || !video.Status.Embeddable {
+		t.Errorf("writable fields not kept: snippet %+v, status %+v", video.Snippet, video.Status)
+	}
+
+	// Read-only fields are dropped
+	if video.Snippet.ChannelId != "" || video.Snippet.PublishedAt != "" || video.Snippet.Thumbnails != nil ||
+		video.Snippet.Localized != nil || video.Snippet.LiveBroadcastContent != "" || video.Status.UploadStatus != "" {
+		t.Errorf("read-only fields kept: snippet %+v, status %+v", video.Snippet, video.Status)
+	}
+
+	// The current video is not modified
+	VideoUpdate{Tags: &[]string{"new"}}.apply(video)
+	if current.Snippet.ChannelId != "UC123" || !slices.Equal(current.Snippet.Tags, []string{"go", "mcp"}) {
+		t.Errorf("current video was modified: %+v", current.Snippet)
+	}
+}
+
+// fakeVideosServer answers videos.list with a video and records videos.update bodies
+func fakeVideosServer(t *testing.T, video *youtube.Video) (*YouTubeClient, *[]map[string]interface{}) {
+	t.Helper()
+	var updates []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(&youtube.VideoListResponse{Items: []*youtube.Video{video}})
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			var update map[string]interface{}
+			json.Unmarshal(body, &update)
+			updates = append(updates, update)
+			w.Write(body)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &Config{APIEndpoint: server.URL + "/"}
+	service, err := newService(context.Background(), cfg, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("newService: %v", err)
+	}
+	return &YouTubeClient{config: cfg, authService: service}, &updates
+}
+
+func TestUpdateVideoDryRun(t *testing.T) {
+	yc, updates := fakeVideosServer(t, testVideo())
+
+	_, changes, err := yc.UpdateVideo("vid1", VideoUpdate{Title: stringPtr("New title"), CategoryID: stringPtr("27")}, true)
+	if err != nil {
+		t.Fatalf("UpdateVideo: %v", err)
+	}
+
+	want := []FieldChange{
+		{Field: "title", Before: "Old title", After: "New title"},
+		{Field: "category_id", Before: "28", After: "27"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %#v, want %#v", changes, want)
+	}
+	if len(*updates) != 0 {
+		t.Errorf("dry run sent %d updates, want none", len(*updates))
+	}
+}
+
+func TestUpdateVideoKeepsUntouchedFields(t *testing.T) {
+	yc, updates := fakeVideosServer(t, testVideo())
+
+	if _, _, err := yc.UpdateVideo("vid1", VideoUpdate{Title: stringPtr("New title")}, false); err != nil {
+		t.Fatalf("UpdateVideo: %v", err)
+	}
+	if len(*updates) != 1 {
+		t.Fatalf("sent %d updates, want 1", len(*updates))
+	}
+
+	sent := (*updates)[0]
+	snippet := sent["snippet"].(map[string]interface{})
+	status := sent["status"].(map[string]interface{})
+	checks := map[string][2]interface{}{
+		"title":                  {snippet["title"], "New title"},
+		"description":            {snippet["description"], "Old description"},
+		"defaultAudioLanguage":   {snippet["defaultAudioLanguage"], "en-US"},
+		"containsSyntheticMedia": {status["containsSyntheticMedia"], true},
+		"publicStatsViewable":    {status["publicStatsViewable"], false},
+		"privacyStatus":          {status["privacyStatus"], "private"},
+		"publishAt":              {status["publishAt"], "2024-02-01T12:00:00Z"},
+		"defaultLanguage":        {snippet["defaultLanguage"], "en"},
+	}
+	for field, check := range checks {
+		if check[0] != check[1] {
+			t.Errorf("%s = %v, want %v", field, check[0], check[1])
+		}
+	}
+	for _, readOnly := range []string{"channelId", "publishedAt", "thumbnails"} {
+		if _, ok := snippet[readOnly]; ok {
+			t.Errorf("read-only snippet.%s was sent", readOnly)
+		}
+	}
+	if _, ok := status["uploadStatus"]; ok {
+		t.Error("read-only status.uploadStatus was sent")
+	}
+}
+
+func TestUpdateVideoSendsClearedFields(t *testing.T) {
+	yc, updates := fakeVideosServer(t, testVideo())
+
+	update := VideoUpdate{DefaultLanguage: stringPtr(""), PublishAt: stringPtr("")}
+	if _, _, err := yc.UpdateVideo("vid1", update, false); err != nil {
+		t.Fatalf("UpdateVideo: %v", err)
+	}
+	if len(*updates) != 1 {
+		t.Fatalf("sent %d updates, want 1", len(*updates))
+	}
+
+	sent := (*updates)[0]
+	snippet := sent["snippet"].(map[string]interface{})
+	status := sent["status"].(map[string]interface{})
+	if value, ok := snippet["defaultLanguage"]; !ok || value != "" {
+		t.Errorf("snippet.defaultLanguage = %v (sent %v), want an explicit empty string", value, ok)
+	}
+	if value, ok := status["publishAt"]; !ok || value != "" {
+		t.Errorf("status.publishAt = %v (sent %v), want an explicit empty string", value, ok)
+	}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+)
+
+// uploadChunkRetryDeadline is how long a failing chunk is retried without
+// progress before the upload gives up
+const uploadChunkRetryDeadline = 5 * time.Minute
+
+// VideoUpload describes a local video file and the metadata to publish it with
+type VideoUpload struct {
+	FilePath      string
+	Title         string
+	Description   string
+	Tags          []string
+	CategoryID    string
+	PrivacyStatus string
+	// PublishAt schedules a private video to become public (RFC 3339)
+	PublishAt   string
+	MadeForKids *bool
+	// SessionURI resumes an interrupted upload of the same file instead of
+	// starting a new one; the metadata fields are then ignored
+	SessionURI string
+}
+
+// UploadVideo uploads a video with the resumable upload protocol. The file is
+// sent in chunks of the configured size; a chunk that fails with a transient
+// error is resumed from the last byte the server acknowledged. If the upload
+// still fails, the error is an *UploadInterruptedError carrying the session
+// URI, which resumes the upload when passed back as SessionURI. progress, if
+// not nil, is called after every chunk with the bytes sent and the file size.
+func (yc *YouTubeClient) UploadVideo(ctx context.Context, upload VideoUpload, progress func(current, total int64)) (*youtube.Video, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(upload.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening video file: %v", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("error reading video file: %v", err)
+	}
+	if stat.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", upload.FilePath)
+	}
+
+	uploader := &resumableUpload{
+		client:        yc.authClient,
+		endpoint:      googleapi.ResolveRelative(service.BasePath, "/upload/youtube/v3/videos"),
+		chunkSize:     int64(yc.config.UploadChunkSize()),
+		retryDeadline: uploadChunkRetryDeadline,
+		retryBackoff:  time.Second,
+		progress:      progress,
+	}
+
+	sessionURI := upload.SessionURI
+	if sessionURI != "" {
+		if err := uploader.checkSession(sessionURI); err != nil {
+			return nil, err
+		}
+	} else {
+		sessionURI, err = uploader.start(ctx, newUploadVideo(upload), []string{"snippet", "status"}, stat.Size(), videoContentType(upload.FilePath))
+		if err != nil {
+			return nil, fmt.Errorf("error starting upload: %v", err)
+		}
+	}
+
+	uploaded, err := uploader.send(ctx, sessionURI, file, stat.Size(), upload.SessionURI != "")
+	if err != nil {
+		return nil, fmt.Errorf("error uploading video: %w", err)
+	}
+
+	return uploaded, nil
+}
+
+// newUploadVideo builds the metadata a new upload session is opened with
+func newUploadVideo(upload VideoUpload) *youtube.Video {
+	video := &youtube.Video{
+		Snippet: &youtube.VideoSnippet{
+			Title:       upload.Title,
+			Description: upload.Description,
+			Tags:        upload.Tags,
+			CategoryId:  upload.CategoryID,
+		},
+		Status: &youtube.VideoStatus{
+			PrivacyStatus: upload.PrivacyStatus,
+			PublishAt:     upload.PublishAt,
+		},
+	}
+	if upload.MadeForKids != nil {
+		video.Status.SelfDeclaredMadeForKids = *upload.MadeForKids
+		video.Status.ForceSendFields = []string{"SelfDeclaredMadeForKids"}
+	}
+	return video
+}
+
+// videoContentType guesses a video file's MIME type from its extension
+func videoContentType(path string) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(path)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// SetThumbnail uploads a custom thumbnail image for a video
+func (yc *YouTubeClient) SetThumbnail(videoID string, image []byte, contentType string) (*youtube.ThumbnailSetResponse, error) {
+	service, err := yc.writeService()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := service.Thumbnails.Set(videoID).
+		Media(bytes.NewReader(image), googleapi.ContentType(contentType)).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("error setting thumbnail: %v", err)
+	}
+
+	return response, nil
+}
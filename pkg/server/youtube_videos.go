@@ -0,0 +1,35 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+)
+
+// GetTrendingVideos gets a region's most popular videos chart, optionally
+// limited to one video category. Each page costs 1 quota unit. A non-empty
+// fields selector limits the response to those fields.
+func (yc *YouTubeClient) GetTrendingVideos(regionCode, categoryID string, maxResults int64, pageToken string, fields googleapi.Field) (*youtube.VideoListResponse, error) {
+	call := yc.service.Videos.List([]string{"snippet", "statistics", "contentDetails"}).
+		Chart("mostPopular").
+		RegionCode(regionCode).
+		MaxResults(maxResults)
+
+	if categoryID != "" {
+		call = call.VideoCategoryId(categoryID)
+	}
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+	if fields != "" {
+		call = call.Fields(fields)
+	}
+
+	response, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("error getting trending videos: %v", err)
+	}
+
+	return response, nil
+}